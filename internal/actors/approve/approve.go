@@ -0,0 +1,306 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/owners"
+)
+
+const (
+	approveActorName = "ApproveActor"
+
+	approveCommand = "approve"
+	cancelArg      = "cancel"
+
+	// statusMarker identifies the approval status comment, which is updated on every '/approve' command.
+	statusMarker = "<!-- actbot:approve -->"
+)
+
+type actor struct {
+	ghClient   *actors.Client
+	logger     *slog.Logger
+	config     config.ApproveConfig
+	repoOwners *owners.Owners
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
+}
+
+// ownersGroup is a set of changed files owned by the same OWNERS file.
+type ownersGroup struct {
+	// dir is the directory of the OWNERS file, empty if no OWNERS file covers the files.
+	dir       string
+	files     []string
+	approvers []string
+}
+
+func NewApproveActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient:   ghClient,
+		logger:     logger,
+		config:     opts.Config.Actors.Approve,
+		repoOwners: opts.Owners,
+	}
+}
+
+func (a *actor) Handler() error {
+	var (
+		issue   = a.event.GetIssue()
+		repo    = a.event.GetRepo()
+		comment = a.event.GetComment()
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), issue.GetNumber())
+
+	comments, err := actors.ListComments(a.ghClient, repo.GetFullName(), issue.GetNumber())
+	if err != nil {
+		return err
+	}
+	// the triggering comment may not be listed yet
+	if !slices.ContainsFunc(comments, func(c *github.IssueComment) bool { return c.GetID() == comment.GetID() }) {
+		comments = append(comments, comment)
+	}
+
+	files, err := actors.ListPRFiles(a.ghClient, repo.GetFullName(), issue.GetNumber())
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.GetFilename())
+		// a renamed file also has to be approved by the owners of its previous location
+		if previous := file.GetPreviousFilename(); len(previous) != 0 {
+			paths = append(paths, previous)
+		}
+	}
+
+	approvedBy := approvers(a.repoOwners, paths, approvals(a.ghClient, comments))
+	pending := pendingGroups(a.repoOwners, paths, approvedBy)
+
+	if len(pending) == 0 {
+		err = actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label)
+	} else {
+		err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label)
+	}
+	if err != nil {
+		return err
+	}
+	a.logger.Infof("pr #%d is approved by %v, %d OWNERS still need to approve", issue.GetNumber(), approvedBy, len(pending))
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return err
+	}
+
+	return actors.UpsertComment(
+		a.ghClient,
+		repo.GetFullName(),
+		issue.GetNumber(),
+		statusMarker,
+		buildStatus(issue.GetUser().GetLogin(), a.config.Label, approvedBy, pending),
+	)
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	genericEvent := event.Event
+	commentEvent, ok := genericEvent.(github.IssueCommentEvent)
+	if !ok {
+		a.logger.Error("cannot extract event to github.IssueCommentEvent, please check event type")
+		return false
+	}
+
+	if !commentEvent.Issue.IsPullRequest() || len(commentEvent.Comment.GetBody()) == 0 {
+		return false
+	}
+	if commentEvent.Issue.GetClosedBy() != nil || !commentEvent.Issue.GetClosedAt().IsZero() {
+		return false
+	}
+
+	commands := parseCommands(commentEvent.Comment.GetBody())
+	if commands == nil {
+		return false
+	}
+	a.event = commentEvent
+	a.commands = commands
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return approveActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:   approveCommand,
+			Syntax: "/approve [cancel]",
+			Description: fmt.Sprintf(
+				"Approves the files of the pull request you own in the OWNERS files, the `%s` label is added once every file is approved.",
+				a.config.Label,
+			),
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/approve' and '/approve cancel' commands of the comment body,
+// commands with other arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, approveCommand) {
+		if len(command.Args) == 0 || isCancel(command) {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func isCancel(command actors.Command) bool {
+	return len(command.Args) == 1 && strings.EqualFold(command.Args[0], cancelArg)
+}
+
+// approvals returns the users whose latest '/approve' command in the comments has not been cancelled,
+// the comments of the bot, such as the status comment, are skipped.
+func approvals(ghClient *actors.Client, comments []*github.IssueComment) []string {
+	approved := make(map[string]bool)
+	for _, comment := range comments {
+		if actors.IsBotComment(ghClient, comment) {
+			continue
+		}
+
+		login := strings.ToLower(comment.GetUser().GetLogin())
+		for _, command := range parseCommands(comment.GetBody()) {
+			approved[login] = !isCancel(command)
+		}
+	}
+
+	var ret []string
+	for login, ok := range approved {
+		if ok {
+			ret = append(ret, login)
+		}
+	}
+	slices.Sort(ret)
+
+	return ret
+}
+
+// approvers returns the users who approved and are approvers of at least one of the files.
+func approvers(repoOwners *owners.Owners, paths, approved []string) []string {
+	var ret []string
+	for _, login := range approved {
+		if slices.ContainsFunc(paths, func(path string) bool { return repoOwners.IsApprover(login, path) }) {
+			ret = append(ret, login)
+		}
+	}
+
+	return ret
+}
+
+// pendingGroups returns the files that are not approved by any of their approvers,
+// grouped by the closest OWNERS file whose approvers are suggested to the author.
+func pendingGroups(repoOwners *owners.Owners, paths, approvedBy []string) []ownersGroup {
+	var groups []ownersGroup
+	for _, path := range paths {
+		if slices.ContainsFunc(repoOwners.Approvers(path), func(approver string) bool {
+			return slices.Contains(approvedBy, approver)
+		}) {
+			continue
+		}
+
+		dir, _ := repoOwners.OwnersDir(path)
+		index := slices.IndexFunc(groups, func(group ownersGroup) bool { return group.dir == dir })
+		if index < 0 {
+			groups = append(groups, ownersGroup{dir: dir, approvers: repoOwners.LeafApprovers(path)})
+			index = len(groups) - 1
+		}
+		groups[index].files = append(groups[index].files, path)
+	}
+
+	return groups
+}
+
+// buildStatus builds the content of the approval status comment.
+func buildStatus(author, label string, approvedBy []string, pending []ownersGroup) string {
+	var b strings.Builder
+
+	if len(pending) == 0 {
+		_, _ = fmt.Fprintf(&b, "**APPROVAL STATUS**: this PR is **APPROVED** and has the `%s` label.\n\n", label)
+	} else {
+		b.WriteString("**APPROVAL STATUS**: this PR is **NOT APPROVED**.\n\n")
+	}
+
+	if len(approvedBy) == 0 {
+		b.WriteString("Approved by: nobody yet.\n")
+	} else {
+		_, _ = fmt.Fprintf(&b, "Approved by: %s.\n", mentions(approvedBy))
+	}
+	if len(pending) == 0 {
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n@%s the following files still need an approval, please ask one of their approvers to comment `/approve`:\n\n", author)
+	for _, group := range pending {
+		switch {
+		case len(group.dir) == 0:
+			_, _ = fmt.Fprintf(&b, "- %s: no OWNERS file covers them, please ask a maintainer for help\n", files(group.files))
+		case len(group.approvers) == 0:
+			_, _ = fmt.Fprintf(&b, "- %s: the `%s` OWNERS file has no approvers, please ask a maintainer for help\n", files(group.files), ownersPath(group.dir))
+		default:
+			_, _ = fmt.Fprintf(&b, "- %s: %s (`%s`)\n", files(group.files), mentions(group.approvers), ownersPath(group.dir))
+		}
+	}
+
+	return b.String()
+}
+
+func mentions(logins []string) string {
+	var ret []string
+	for _, login := range logins {
+		ret = append(ret, "@"+login)
+	}
+
+	return strings.Join(ret, ", ")
+}
+
+func files(paths []string) string {
+	var ret []string
+	for _, path := range paths {
+		ret = append(ret, "`"+path+"`")
+	}
+
+	return strings.Join(ret, ", ")
+}
+
+func ownersPath(dir string) string {
+	if dir == "." {
+		return owners.FileName
+	}
+
+	return dir + "/" + owners.FileName
+}
@@ -0,0 +1,339 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/owners"
+)
+
+func TestApproveCommentBodyMatch(t *testing.T) {
+	cases := []struct {
+		caseName string
+		comment  string
+		expect   []actors.Command
+	}{
+		{
+			caseName: "Match the approve instruction",
+			comment:  "/approve",
+			expect:   []actors.Command{{Name: approveCommand}},
+		},
+		{
+			caseName: "Match the approve cancel instruction",
+			comment:  "/approve cancel",
+			expect:   []actors.Command{{Name: approveCommand, Args: []string{cancelArg}}},
+		},
+		{
+			caseName: "unmatched approve instruction with unknown arguments",
+			comment:  "/approve no-issue",
+			expect:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, parseCommands(tc.comment))
+		})
+	}
+}
+
+func TestApproveCapture(t *testing.T) {
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "approve actor capture approve command on pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/approve"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: &github.PullRequestLinks{},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "approve actor does not capture issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/approve"),
+					},
+					Issue: &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			approveActor := &actor{
+				// a noop logger for testing only
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			assert.Equal(t, tc.expect, approveActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestApprovals(t *testing.T) {
+	comment := func(login, body string) *github.IssueComment {
+		user := &github.User{Login: github.Ptr(login), Type: github.Ptr("User")}
+		if login == actors.DefaultBotLogin {
+			user.Type = github.Ptr("Bot")
+		}
+		return &github.IssueComment{User: user, Body: github.Ptr(body)}
+	}
+
+	comments := []*github.IssueComment{
+		comment("Alice", "/approve"),
+		comment("bob", "/approve"),
+		comment("bob", "/approve cancel"),
+		comment("carol", "> /approve"),
+		comment("dave", "/approve cancel\n/approve"),
+		comment(actors.DefaultBotLogin, statusMarker+"\n/approve"),
+		// the marker in the comment of a user does not make it the status comment
+		comment("erin", statusMarker+"\n/approve"),
+	}
+	assert.Equal(t, []string{"alice", "dave", "erin"}, approvals(fake.New().Client(), comments))
+}
+
+func TestPendingGroups(t *testing.T) {
+	repoOwners, err := owners.New(
+		map[string][]byte{
+			"OWNERS":         []byte("approvers: [alice]\n"),
+			"pkg/api/OWNERS": []byte("approvers: [bob, carol]\n"),
+			"docs/OWNERS":    []byte("approvers: []\noptions:\n  no_parent_owners: true\n"),
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+	paths := []string{"main.go", "pkg/api/types.go", "pkg/api/v1/server.go", "docs/README.md"}
+
+	cases := []struct {
+		caseName   string
+		approved   []string
+		approvedBy []string
+		expect     []ownersGroup
+	}{
+		{
+			caseName:   "Root approvers cover every file except the ones without parent owners",
+			approved:   []string{"alice", "mallory"},
+			approvedBy: []string{"alice"},
+			expect: []ownersGroup{
+				{dir: "docs", files: []string{"docs/README.md"}},
+			},
+		},
+		{
+			caseName:   "Directory approvers only cover their directory",
+			approved:   []string{"bob"},
+			approvedBy: []string{"bob"},
+			expect: []ownersGroup{
+				{dir: ".", files: []string{"main.go"}, approvers: []string{"alice"}},
+				{dir: "docs", files: []string{"docs/README.md"}},
+			},
+		},
+		{
+			caseName:   "Nobody approved",
+			approved:   nil,
+			approvedBy: nil,
+			expect: []ownersGroup{
+				{dir: ".", files: []string{"main.go"}, approvers: []string{"alice"}},
+				{dir: "pkg/api", files: []string{"pkg/api/types.go", "pkg/api/v1/server.go"}, approvers: []string{"bob", "carol"}},
+				{dir: "docs", files: []string{"docs/README.md"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			approvedBy := approvers(repoOwners, paths, tc.approved)
+			assert.Equal(t, tc.approvedBy, approvedBy)
+			assert.Equal(t, tc.expect, pendingGroups(repoOwners, paths, approvedBy))
+		})
+	}
+}
+
+func TestPendingGroupsWithoutOwners(t *testing.T) {
+	assert.Equal(
+		t,
+		[]ownersGroup{{files: []string{"main.go"}}},
+		pendingGroups(nil, []string{"main.go"}, nil),
+	)
+}
+
+func TestBuildStatus(t *testing.T) {
+	approved := buildStatus("author", "approved", []string{"alice"}, nil)
+	assert.Contains(t, approved, "**APPROVED**")
+	assert.Contains(t, approved, "Approved by: @alice.")
+
+	pending := buildStatus("author", "approved", nil, []ownersGroup{
+		{dir: "pkg/api", files: []string{"pkg/api/types.go"}, approvers: []string{"bob", "carol"}},
+		{files: []string{"main.go"}},
+	})
+	assert.Contains(t, pending, "**NOT APPROVED**")
+	assert.Contains(t, pending, "@author the following files still need an approval")
+	assert.Contains(t, pending, "- `pkg/api/types.go`: @bob, @carol (`pkg/api/OWNERS`)")
+	assert.Contains(t, pending, "- `main.go`: no OWNERS file covers them")
+	// the status comment must not trigger commands itself
+	assert.Nil(t, actors.ParseCommands(pending))
+}
+
+func TestApproveHandler(t *testing.T) {
+	repoOwners, err := owners.New(
+		map[string][]byte{
+			"OWNERS":         []byte("approvers: [alice]\n"),
+			"pkg/api/OWNERS": []byte("approvers: [bob]\n"),
+			"docs/OWNERS":    []byte("approvers: [dave]\noptions:\n  no_parent_owners: true\n"),
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+
+	var (
+		bot     = &github.User{Login: github.Ptr(actors.DefaultBotLogin), Type: github.Ptr("Bot")}
+		bob     = &github.User{Login: github.Ptr("bob"), Type: github.Ptr("User")}
+		mallory = &github.User{Login: github.Ptr("mallory"), Type: github.Ptr("User")}
+	)
+
+	cases := []struct {
+		caseName       string
+		comment        string
+		comments       []*github.IssueComment
+		files          []*github.CommitFile
+		labels         []string
+		expectLabels   []string
+		expectComments int
+		expectStatus   string
+	}{
+		{
+			caseName:       "Approve every file",
+			comment:        "/approve",
+			files:          []*github.CommitFile{{Filename: github.Ptr("pkg/api/types.go")}},
+			expectLabels:   []string{"approved"},
+			expectComments: 1,
+			expectStatus:   "this PR is **APPROVED** and has the `approved` label.\n\nApproved by: @bob.",
+		},
+		{
+			caseName: "Require the approval of the owners of the previous path of a renamed file",
+			comment:  "/approve",
+			files: []*github.CommitFile{
+				{Filename: github.Ptr("pkg/api/guide.md"), PreviousFilename: github.Ptr("docs/guide.md")},
+			},
+			labels:         []string{"approved"},
+			expectLabels:   nil,
+			expectComments: 1,
+			expectStatus:   "- `docs/guide.md`: @dave (`docs/OWNERS`)",
+		},
+		{
+			caseName: "Cancel the approval",
+			comment:  "/approve cancel",
+			comments: []*github.IssueComment{
+				{ID: github.Ptr(int64(1)), User: bob, Body: github.Ptr("/approve")},
+			},
+			files:          []*github.CommitFile{{Filename: github.Ptr("pkg/api/types.go")}},
+			labels:         []string{"approved"},
+			expectLabels:   nil,
+			expectComments: 2,
+			expectStatus:   "this PR is **NOT APPROVED**.\n\nApproved by: nobody yet.",
+		},
+		{
+			caseName: "Update the status comment of the bot only",
+			comment:  "/approve",
+			comments: []*github.IssueComment{
+				{ID: github.Ptr(int64(1)), User: mallory, Body: github.Ptr(statusMarker + "\nApproved by: @mallory.")},
+				{ID: github.Ptr(int64(2)), User: bot, Body: github.Ptr(statusMarker + "\nApproved by: nobody yet.")},
+			},
+			files:          []*github.CommitFile{{Filename: github.Ptr("pkg/api/types.go")}},
+			expectLabels:   []string{"approved"},
+			expectComments: 2,
+			expectStatus:   "Approved by: @bob.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			var labels []*github.Label
+			for _, label := range tc.labels {
+				labels = append(labels, &github.Label{Name: github.Ptr(label)})
+			}
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr("open"),
+				User:             &github.User{Login: github.Ptr("erin")},
+				Labels:           labels,
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+			}
+			gh.Comments[1] = tc.comments
+			gh.Files[1] = tc.files
+
+			approveActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config:     config.Default().Actors.Approve,
+				repoOwners: repoOwners,
+			}
+			captured := approveActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr(tc.comment), User: bob},
+					Issue:   gh.Issues[1],
+					Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+			before := gh.CommentBodies(1)
+			assert.NoError(t, approveActor.Handler())
+
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+
+			// the status comment of the bot is the last one
+			bodies := gh.CommentBodies(1)
+			if assert.Len(t, bodies, tc.expectComments) {
+				status := bodies[len(bodies)-1]
+				assert.True(t, strings.HasPrefix(status, statusMarker+"\n"))
+				assert.Contains(t, status, tc.expectStatus)
+			}
+			// the comments of the users are never edited
+			for i, comment := range tc.comments {
+				if !actors.IsBotComment(gh.Client(), comment) {
+					assert.Equal(t, before[i], bodies[i])
+				}
+			}
+		})
+	}
+}
@@ -15,8 +15,8 @@
 package area
 
 import (
-	"regexp"
-	"strings"
+	"errors"
+	"fmt"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
@@ -26,52 +26,54 @@ import (
 
 const (
 	areaLabelerActorName = "AreaLabelerActor"
-	areaPrefix           = "area/"
-)
 
-var (
-	areaRegexp   = regexp.MustCompile(`^/area\s+(.+)$`)
-	unareaRegexp = regexp.MustCompile(`^/unarea\s+(.+)$`)
+	areaCommand   = "area"
+	unareaCommand = "unarea"
 )
 
 type actor struct {
-	ghClient *github.Client
+	ghClient *actors.Client
 	logger   *slog.Logger
 
-	event github.IssueCommentEvent
+	// prefix is prepended to the command arguments to build the label names.
+	prefix string
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
 }
 
-func NewLabelerActor(ghClient *github.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+func NewLabelerActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
 	return &actor{
 		ghClient: ghClient,
 		logger:   logger,
+		prefix:   opts.Config.Actors.Area.Prefix,
 	}
 }
 
 func (a *actor) Handler() error {
 	var (
-		issue   = a.event.GetIssue()
-		repo    = a.event.GetRepo()
-		comment = a.event.GetComment()
-		body    = comment.GetBody()
+		issue = a.event.GetIssue()
+		repo  = a.event.GetRepo()
+		err   error
 	)
 	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
 
-	var err error
-	if areaMatch := areaRegexp.FindStringSubmatch(body); areaMatch != nil {
-		labels := strings.Fields(areaMatch[1])
-		for _, label := range labels {
-			label = areaPrefix + label
-			err = actors.CheckAndAddLabel(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
-			if err != nil {
-				return err
+	for _, command := range a.commands {
+		for _, arg := range command.Args {
+			label := a.prefix + arg
+			if command.Name == areaCommand {
+				err = actors.CheckAndAddLabel(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
+			} else {
+				err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
+			}
+			var notFound *actors.LabelNotFoundError
+			if errors.As(err, &notFound) {
+				return &actors.CommandError{
+					Command: fmt.Sprintf("/%s %s", command.Name, arg),
+					Reason:  fmt.Sprintf("the `%s` label does not exist", label),
+					Options: actors.LabelOptions(notFound.Labels, a.prefix),
+				}
 			}
-		}
-	} else if unareaMatch := unareaRegexp.FindStringSubmatch(body); unareaMatch != nil {
-		labels := strings.Fields(unareaMatch[1])
-		for _, label := range labels {
-			label = areaPrefix + label
-			err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
 			if err != nil {
 				return err
 			}
@@ -104,16 +106,50 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 		return false
 	}
 
-	if areaRegexp.MatchString(commentEvent.Comment.GetBody()) ||
-		unareaRegexp.MatchString(commentEvent.Comment.GetBody()) {
-
-		a.event = commentEvent
-		return true
+	commands := parseCommands(commentEvent.Comment.GetBody())
+	if commands == nil {
+		return false
 	}
+	a.event = commentEvent
+	a.commands = commands
 
-	return false
+	return true
 }
 
 func (a *actor) Name() string {
 	return areaLabelerActorName
 }
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:        areaCommand,
+			Syntax:      "/area <area>...",
+			Description: fmt.Sprintf("Adds the `%s<area>` labels to the issue.", a.prefix),
+			Issues:      true,
+		},
+		{
+			Name:        unareaCommand,
+			Syntax:      "/unarea <area>...",
+			Description: fmt.Sprintf("Removes the `%s<area>` labels from the issue.", a.prefix),
+			Issues:      true,
+		},
+	}
+}
+
+// parseCommands returns the area commands of the comment body,
+// commands without any label are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, areaCommand, unareaCommand) {
+		if len(command.Args) != 0 {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
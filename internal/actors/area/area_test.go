@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
 )
 
 func TestLabelerCommentBodyMatch(t *testing.T) {
@@ -42,6 +43,16 @@ func TestLabelerCommentBodyMatch(t *testing.T) {
 			comment:  "/unarea label1",
 			expect:   true,
 		},
+		{
+			caseName: "Match area instruction among other instructions",
+			comment:  "/assign\n/area label1 label2",
+			expect:   true,
+		},
+		{
+			caseName: "Unmatched area instruction without label",
+			comment:  "/area",
+			expect:   false,
+		},
 		{
 			caseName: "Unmatched instruction",
 			comment:  "/label",
@@ -51,11 +62,7 @@ func TestLabelerCommentBodyMatch(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
-			var regexpMatch bool
-			if areaRegexp.MatchString(tc.comment) || unareaRegexp.MatchString(tc.comment) {
-				regexpMatch = true
-			}
-			assert.Equal(t, tc.expect, regexpMatch)
+			assert.Equal(t, tc.expect, parseCommands(tc.comment) != nil)
 		})
 	}
 }
@@ -135,3 +142,70 @@ func TestLabelerCapture(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelerHandler(t *testing.T) {
+	cases := []struct {
+		caseName     string
+		comment      string
+		labels       []string
+		expectLabels []string
+		expectErr    error
+	}{
+		{
+			caseName:     "Add labels",
+			comment:      "/area core runtime",
+			labels:       []string{actors.NeedsTriageLabel},
+			expectLabels: []string{"area/core", "area/runtime"},
+		},
+		{
+			caseName:     "Remove label",
+			comment:      "/unarea core",
+			labels:       []string{"area/core", "area/runtime"},
+			expectLabels: []string{"area/runtime"},
+		},
+		{
+			caseName:     "Add unknown label",
+			comment:      "/area unknown",
+			labels:       []string{actors.NeedsTriageLabel},
+			expectLabels: []string{actors.NeedsTriageLabel},
+			expectErr: &actors.CommandError{
+				Command: "/area unknown",
+				Reason:  "the `area/unknown` label does not exist",
+				Options: []string{"api", "core", "runtime"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			for _, name := range []string{"area/core", "area/runtime", "area/api", actors.NeedsTriageLabel} {
+				gh.Labels = append(gh.Labels, &github.Label{Name: github.Ptr(name)})
+			}
+			gh.Issues[1] = &github.Issue{Number: github.Ptr(1), State: github.Ptr("open")}
+			for _, name := range tc.labels {
+				gh.Issues[1].Labels = append(gh.Issues[1].Labels, &github.Label{Name: github.Ptr(name)})
+			}
+
+			labelerActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				prefix: "area/",
+			}
+			captured := labelerActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr(tc.comment)},
+					Issue:   gh.Issues[1],
+					Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+
+			err := labelerActor.Handler()
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+		})
+	}
+}
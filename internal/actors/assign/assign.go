@@ -15,101 +15,253 @@
 package assign
 
 import (
-	"context"
 	"fmt"
-	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/permission"
 )
 
 const (
 	assignActorName = "AssignActor"
-)
 
-var assignRegexp = regexp.MustCompile(`^/(un)?assign\b`)
+	assignCommand   = "assign"
+	unassignCommand = "unassign"
+)
 
 type actor struct {
-	ghClient *github.Client
+	ghClient *actors.Client
 	logger   *slog.Logger
+	config   config.AssignConfig
+
+	// permissions checks the role of the commenter when assigning other users.
+	permissions *permission.Checker
 
-	event github.IssueCommentEvent
-	add   bool
+	event    github.IssueCommentEvent
+	commands []actors.Command
 }
 
-func NewAssignActor(ghClient *github.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+func NewAssignActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
 	return &actor{
-		ghClient: ghClient,
-		logger:   logger,
-		add:      true,
+		ghClient:    ghClient,
+		logger:      logger,
+		config:      opts.Config.Actors.Assign,
+		permissions: opts.Permissions,
 	}
 }
 
 func (a *actor) Handler() error {
+	issue := a.event.GetIssue()
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	var (
+		repo      = a.event.GetRepo()
+		loginUser = a.event.GetComment().GetUser().GetLogin()
+		replies   []string
+		failures  []string
+	)
+	for _, command := range a.commands {
+		targets := parseAssignees(command.Args)
+		// a command without other users refers to the commenter
+		if len(targets) == 0 || (len(targets) == 1 && strings.EqualFold(targets[0], loginUser)) {
+			reply, err := a.handleCommand(command.Name == assignCommand)
+			if err != nil {
+				return err
+			}
+			if len(reply) != 0 {
+				replies = append(replies, reply)
+			}
+			continue
+		}
+
+		allowed, err := a.permissions.HasRole(loginUser, a.config.OthersRole)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			reply := fmt.Sprintf("only the users with the `%s` role in this repository can assign other users.", a.config.OthersRole)
+			if !slices.Contains(replies, reply) {
+				replies = append(replies, reply)
+			}
+			continue
+		}
+
+		failed, err := a.handleOthers(command.Name == assignCommand, targets)
+		if err != nil {
+			return err
+		}
+		failures = append(failures, failed...)
+	}
+
+	if len(failures) != 0 {
+		replies = append(replies, fmt.Sprintf("the following users could not be assigned:\n\n%s", strings.Join(failures, "\n")))
+	}
+	if len(replies) != 0 {
+		return actors.AddComment(
+			a.ghClient,
+			fmt.Sprintf("@%s %s", loginUser, strings.Join(replies, "\n\n")),
+			repo.GetFullName(),
+			issue.GetNumber(),
+		)
+	}
+
+	return nil
+}
+
+// currentAssignees returns the users the issue is assigned to right now,
+// the assignees of the event are stale once a previous command has changed them.
+func (a *actor) currentAssignees() ([]*github.User, error) {
+	owner, repoName := actors.GetOwnerRepo(a.event.GetRepo().GetFullName())
+	issue, _, err := a.ghClient.Issues.Get(a.ghClient.Context(), owner, repoName, a.event.GetIssue().GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	return issue.Assignees, nil
+}
+
+// handleOthers assigns or unassigns the issue to the target users and
+// returns a description of each user that could not be assigned.
+func (a *actor) handleOthers(add bool, targets []string) ([]string, error) {
+	var (
+		issue   = a.event.GetIssue()
+		comment = a.event.GetComment()
+		repo    = a.event.GetRepo()
+		failed  []string
+		valid   []string
+	)
+
+	owner, repoName := actors.GetOwnerRepo(repo.GetFullName())
+	if add {
+		assignees, err := a.currentAssignees()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, target := range targets {
+			// the users already assigned are not checked again
+			if isAssignLoginUser(&github.User{Login: github.Ptr(target)}, assignees) {
+				valid = append(valid, target)
+				continue
+			}
+
+			assignable, _, err := a.ghClient.Issues.IsAssignee(a.ghClient.Context(), owner, repoName, target)
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("- @%s: %v", target, err))
+				continue
+			}
+			if !assignable {
+				failed = append(failed, fmt.Sprintf("- @%s: cannot be assigned to issues of this repository", target))
+				continue
+			}
+
+			reason, err := a.policyViolation(repo.GetFullName(), comment.GetUser().GetLogin(), target, assignees)
+			if err != nil {
+				return nil, err
+			}
+			if len(reason) != 0 {
+				failed = append(failed, fmt.Sprintf("- @%s: %s", target, reason))
+				continue
+			}
+
+			valid = append(valid, target)
+			// the users assigned by the command count in the assignees limit of the next ones
+			assignees = append(assignees, &github.User{Login: github.Ptr(target)})
+		}
+		if len(valid) == 0 {
+			return failed, nil
+		}
+
+		if _, _, err := a.ghClient.Issues.AddAssignees(a.ghClient.Context(), owner, repoName, issue.GetNumber(), valid); err != nil {
+			return nil, err
+		}
+		a.logger.Infof("assigned issue to %v", valid)
+
+		if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), actors.HelpWantedLabel); err != nil {
+			return nil, err
+		}
+		a.logger.Infof("remove '%s' label from issue #%d", actors.HelpWantedLabel, issue.GetNumber())
+	} else {
+		if _, _, err := a.ghClient.Issues.RemoveAssignees(a.ghClient.Context(), owner, repoName, issue.GetNumber(), targets); err != nil {
+			return nil, err
+		}
+		a.logger.Infof("unassigned issue to %v", targets)
+	}
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return nil, err
+	}
+	a.logger.Infof("add a reaction '%s' to comment %d of issue #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+
+	return failed, nil
+}
+
+// handleCommand assigns or unassigns the issue to the commenter and
+// returns the reply to the commenter when the command cannot be applied.
+func (a *actor) handleCommand(add bool) (string, error) {
 	var (
 		issue     = a.event.GetIssue()
 		comment   = a.event.GetComment()
 		loginUser = comment.GetUser()
 		repo      = a.event.GetRepo()
-		assignees = issue.Assignees
 	)
-	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	assignees, err := a.currentAssignees()
+	if err != nil {
+		return "", err
+	}
 
 	owner, repoName := actors.GetOwnerRepo(repo.GetFullName())
-	if a.add {
+	if add {
 		// if it has been assigned to the login user, we will write back a comment
 		if isAssignLoginUser(loginUser, assignees) {
-			err := actors.AddComment(
-				a.ghClient,
-				fmt.Sprintf("@%s %s", loginUser.GetLogin(), "The issue has been assigned to you. Please do not attempt to assign it"),
-				repo.GetFullName(),
-				issue.GetNumber(),
-			)
-			return err
+			return a.config.AlreadyAssignedMessage, nil
+		}
+
+		reason, err := a.policyViolation(repo.GetFullName(), loginUser.GetLogin(), loginUser.GetLogin(), assignees)
+		if err != nil || len(reason) != 0 {
+			return reason, err
 		}
 
 		if _, _, err := a.ghClient.Issues.AddAssignees(
-			context.Background(),
+			a.ghClient.Context(),
 			owner,
 			repoName,
 			issue.GetNumber(),
 			[]string{loginUser.GetLogin()},
 		); err != nil {
-			return err
+			return "", err
 		}
 		a.logger.Infof("assigned issue to '%s'", loginUser.GetLogin())
 
 		if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
-			return err
+			return "", err
 		}
 		a.logger.Infof("add a reaction '%s' to comment %d of issue #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
 
 		if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), actors.HelpWantedLabel); err != nil {
-			return err
+			return "", err
 		}
 		a.logger.Infof("remove '%s' label from issue #%d", actors.HelpWantedLabel, issue.GetNumber())
 	} else {
 		// if it has been unassigned to the login user, we will write back a comment
 		if !isAssignLoginUser(loginUser, assignees) {
-			err := actors.AddComment(
-				a.ghClient,
-				fmt.Sprintf("@%s %s", loginUser.GetLogin(), "This issue is no assigned to you. Please do not try to unassign it again"),
-				repo.GetFullName(),
-				issue.GetNumber(),
-			)
-			return err
+			return a.config.NotAssignedMessage, nil
 		}
 
 		if _, _, err := a.ghClient.Issues.RemoveAssignees(
-			context.Background(),
+			a.ghClient.Context(),
 			owner,
 			repoName,
 			issue.GetNumber(),
 			[]string{loginUser.GetLogin()},
 		); err != nil {
-			return err
+			return "", err
 		}
 
 		// This is left to the contributors, so don't include the 'help-wanted' tag
@@ -121,7 +273,53 @@ func (a *actor) Handler() error {
 		a.logger.Infof("unassigned issue to '%s'", loginUser.GetLogin())
 	}
 
-	return nil
+	return "", nil
+}
+
+// policyViolation returns the reason why the assignment policy does not allow the commenter
+// to assign the issue to the user, or an empty string when it does. The policy does not
+// limit the commenters with the override role.
+func (a *actor) policyViolation(repoFullName, commenter, login string, assignees []*github.User) (string, error) {
+	if a.config.MaxAssignees == 0 && a.config.MaxOpenIssues == 0 {
+		return "", nil
+	}
+
+	override, err := a.permissions.HasRole(commenter, a.config.OverrideRole)
+	if err != nil {
+		return "", err
+	}
+	if override {
+		return "", nil
+	}
+
+	self := strings.EqualFold(commenter, login)
+	if a.config.MaxAssignees != 0 && len(assignees) >= a.config.MaxAssignees {
+		if !self {
+			return fmt.Sprintf("this issue is already assigned to %s", mentions(assignees)), nil
+		}
+		return fmt.Sprintf(
+			"this issue is already assigned to %s, please pick another issue or ask them whether they are still working on it.",
+			mentions(assignees),
+		), nil
+	}
+
+	if a.config.MaxOpenIssues != 0 {
+		issues, err := actors.ListOpenIssuesByAssignee(a.ghClient, repoFullName, login)
+		if err != nil {
+			return "", err
+		}
+		if len(issues) >= a.config.MaxOpenIssues {
+			if !self {
+				return fmt.Sprintf("already assigned to %d open issues of this repository", len(issues)), nil
+			}
+			return fmt.Sprintf(
+				"you are already assigned to %d open issues of this repository, please finish some of them before claiming a new one.",
+				len(issues),
+			), nil
+		}
+	}
+
+	return "", nil
 }
 
 func (a *actor) Capture(event actors.GenericEvent) bool {
@@ -147,16 +345,12 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 		return false
 	}
 
-	matches := assignRegexp.FindAllStringSubmatch(comment.GetBody(), -1)
-	if matches == nil {
+	commands := actors.ParseCommands(comment.GetBody(), assignCommand, unassignCommand)
+	if commands == nil {
 		return false
 	}
-	for _, match := range matches {
-		if match[1] == "un" {
-			a.add = false
-		}
-	}
 	a.event = commentEvent
+	a.commands = commands
 
 	return true
 }
@@ -165,13 +359,74 @@ func (a *actor) Name() string {
 	return assignActorName
 }
 
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:        assignCommand,
+			Syntax:      "/assign",
+			Description: "Assigns the issue to yourself.",
+			Issues:      true,
+		},
+		{
+			Name:        assignCommand,
+			Syntax:      "/assign @user...",
+			Description: "Assigns the issue to other users.",
+			Role:        a.config.OthersRole,
+			Issues:      true,
+		},
+		{
+			Name:        unassignCommand,
+			Syntax:      "/unassign",
+			Description: "Unassigns yourself from the issue.",
+			Issues:      true,
+		},
+		{
+			Name:        unassignCommand,
+			Syntax:      "/unassign @user...",
+			Description: "Unassigns other users from the issue.",
+			Role:        a.config.OthersRole,
+			Issues:      true,
+		},
+	}
+}
+
+// parseAssignees parses the '@user' arguments of a command,
+// arguments may also be separated by commas.
+func parseAssignees(args []string) []string {
+	var ret []string
+	for _, arg := range args {
+		for _, target := range strings.Split(arg, ",") {
+			target = strings.TrimPrefix(strings.TrimSpace(target), "@")
+			if len(target) != 0 && !slices.Contains(ret, target) {
+				ret = append(ret, target)
+			}
+		}
+	}
+
+	return ret
+}
+
+// mentions returns the '@login' mentions of the users separated by commas.
+func mentions(users []*github.User) string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, "@"+user.GetLogin())
+	}
+
+	return strings.Join(logins, ", ")
+}
+
 func isAssignLoginUser(user *github.User, assignees []*github.User) bool {
 	if len(assignees) == 0 {
 		return false
 	}
 
 	for _, assignee := range assignees {
-		if assignee.GetID() == user.GetID() {
+		if strings.EqualFold(assignee.GetLogin(), user.GetLogin()) {
 			return true
 		}
 	}
@@ -25,34 +25,55 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/permission"
 )
 
 func TestAssignCommentBodyMatch(t *testing.T) {
 	cases := []struct {
 		caseName string
 		comment  string
-		expect   [][]string
+		expect   []actors.Command
 	}{
 		{
 			caseName: "Match the assign instruction",
 			comment:  "/assign",
-			expect: [][]string{
-				{
-					"/assign",
-					"",
-				},
+			expect: []actors.Command{
+				{Name: assignCommand},
 			},
 		},
 		{
 			caseName: "Match the unassign instruction",
 			comment:  "/unassign",
-			expect: [][]string{
-				{
-					"/unassign",
-					"un",
-				},
+			expect: []actors.Command{
+				{Name: unassignCommand},
+			},
+		},
+		{
+			caseName: "Match the assign instruction among other instructions",
+			comment:  "/kind bug\n/area core\n/assign",
+			expect: []actors.Command{
+				{Name: assignCommand},
+			},
+		},
+		{
+			caseName: "Match the assign instruction with users",
+			comment:  "/assign @alice @bob",
+			expect: []actors.Command{
+				{Name: assignCommand, Args: []string{"@alice", "@bob"}},
 			},
 		},
+		{
+			caseName: "unmatched quoted assign instruction",
+			comment:  "> /assign\nI would like to work on it too",
+			expect:   nil,
+		},
+		{
+			caseName: "unmatched assign instruction in code block",
+			comment:  "Comment the following to claim it:\n```\n/assign\n```",
+			expect:   nil,
+		},
 		{
 			caseName: "unmatched instructions",
 			comment:  "/foo",
@@ -62,13 +83,38 @@ func TestAssignCommentBodyMatch(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
-			match := assignRegexp.FindAllStringSubmatch(tc.comment, -1)
-			if tc.expect != nil {
-				assert.NotNil(t, match)
-				assert.ElementsMatch(t, tc.expect, match)
-			} else {
-				assert.Nil(t, match)
-			}
+			match := actors.ParseCommands(tc.comment, assignCommand, unassignCommand)
+			assert.Equal(t, tc.expect, match)
+		})
+	}
+}
+
+func TestParseAssignees(t *testing.T) {
+	cases := []struct {
+		caseName string
+		args     []string
+		expect   []string
+	}{
+		{
+			caseName: "No users",
+			args:     nil,
+			expect:   nil,
+		},
+		{
+			caseName: "Users with and without '@'",
+			args:     []string{"@alice", "bob"},
+			expect:   []string{"alice", "bob"},
+		},
+		{
+			caseName: "Comma separated users",
+			args:     []string{"@alice,@bob,", "@alice"},
+			expect:   []string{"alice", "bob"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, parseAssignees(tc.args))
 		})
 	}
 }
@@ -132,7 +178,7 @@ func TestAssignCapture(t *testing.T) {
 			expect: false,
 		},
 		{
-			caseName: "assign actor does not capture unmatched assignRegexp comment body issue",
+			caseName: "assign actor does not capture unmatched assign command comment body issue",
 			event: actors.GenericEvent{
 				Event: github.IssueCommentEvent{
 					Comment: &github.IssueComment{
@@ -157,3 +203,276 @@ func TestAssignCapture(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyViolation(t *testing.T) {
+	cases := []struct {
+		caseName   string
+		config     func(cfg *config.AssignConfig)
+		role       string
+		assignees  []*github.User
+		openIssues int
+		expect     bool
+	}{
+		{
+			caseName: "Claim unassigned issue",
+			role:     "read",
+			expect:   false,
+		},
+		{
+			caseName:  "Claim issue assigned to someone else",
+			role:      "read",
+			assignees: []*github.User{{Login: github.Ptr("bob")}},
+			expect:    true,
+		},
+		{
+			caseName:  "Maintainers override the policy",
+			role:      "triage",
+			assignees: []*github.User{{Login: github.Ptr("bob")}},
+			expect:    false,
+		},
+		{
+			caseName: "Claim issue below the assignees limit",
+			config: func(cfg *config.AssignConfig) {
+				cfg.MaxAssignees = 2
+			},
+			role:      "read",
+			assignees: []*github.User{{Login: github.Ptr("bob")}},
+			expect:    false,
+		},
+		{
+			caseName: "Claim issue with the assignees limit disabled",
+			config: func(cfg *config.AssignConfig) {
+				cfg.MaxAssignees = 0
+			},
+			assignees: []*github.User{{Login: github.Ptr("bob")}, {Login: github.Ptr("carol")}},
+			expect:    false,
+		},
+		{
+			caseName: "Claim issue below the open issues quota",
+			config: func(cfg *config.AssignConfig) {
+				cfg.MaxOpenIssues = 2
+			},
+			role:       "read",
+			openIssues: 1,
+			expect:     false,
+		},
+		{
+			caseName: "Claim issue reaching the open issues quota",
+			config: func(cfg *config.AssignConfig) {
+				cfg.MaxOpenIssues = 2
+			},
+			role:       "read",
+			openIssues: 2,
+			expect:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			if len(tc.role) != 0 {
+				gh.Collaborators["alice"] = tc.role
+			}
+			// pull requests do not count in the quota
+			gh.Issues[100] = &github.Issue{
+				Number:           github.Ptr(100),
+				State:            github.Ptr("open"),
+				PullRequestLinks: &github.PullRequestLinks{},
+				Assignees:        []*github.User{{Login: github.Ptr("alice")}},
+			}
+			for i := range tc.openIssues {
+				gh.Issues[i+1] = &github.Issue{
+					Number:    github.Ptr(i + 1),
+					State:     github.Ptr("open"),
+					Assignees: []*github.User{{Login: github.Ptr("alice")}},
+				}
+			}
+			ghClient := gh.Client()
+
+			cfg := config.Default().Actors.Assign
+			if tc.config != nil {
+				tc.config(&cfg)
+			}
+			assignActor := &actor{
+				ghClient:    ghClient,
+				config:      cfg,
+				permissions: permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+			}
+
+			reason, err := assignActor.policyViolation("owner/repo", "alice", "alice", tc.assignees)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, len(reason) != 0, reason)
+		})
+	}
+}
+
+func TestAssignHandler(t *testing.T) {
+	var (
+		alice = &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("alice")}
+		bob   = &github.User{ID: github.Ptr(int64(2)), Login: github.Ptr("bob")}
+		carol = &github.User{ID: github.Ptr(int64(3)), Login: github.Ptr("carol")}
+	)
+
+	cases := []struct {
+		caseName        string
+		config          func(cfg *config.AssignConfig)
+		commenter       *github.User
+		comment         string
+		assignees       []*github.User
+		expectAssignees []string
+		expectComment   string
+		expectReaction  bool
+	}{
+		{
+			caseName:        "Assign the commenter",
+			commenter:       alice,
+			comment:         "/assign",
+			expectAssignees: []string{"alice"},
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Assign the commenter already assigned",
+			commenter:       alice,
+			comment:         "/assign",
+			assignees:       []*github.User{alice},
+			expectAssignees: []string{"alice"},
+			expectComment:   "@alice The issue has been assigned to you",
+		},
+		{
+			caseName:        "Assign the commenter to an issue assigned to someone else",
+			commenter:       alice,
+			comment:         "/assign",
+			assignees:       []*github.User{bob},
+			expectAssignees: []string{"bob"},
+			expectComment:   "@alice this issue is already assigned to @bob",
+		},
+		{
+			caseName:        "Unassign the commenter",
+			commenter:       alice,
+			comment:         "/unassign",
+			assignees:       []*github.User{alice, bob},
+			expectAssignees: []string{"bob"},
+		},
+		{
+			caseName:        "Unassign the commenter not assigned",
+			commenter:       alice,
+			comment:         "/unassign",
+			assignees:       []*github.User{bob},
+			expectAssignees: []string{"bob"},
+			expectComment:   "@alice This issue is no assigned to you",
+		},
+		{
+			caseName:        "Maintainer assigns other users",
+			commenter:       bob,
+			comment:         "/assign @alice @carol",
+			expectAssignees: []string{"alice"},
+			expectComment:   "- @carol: cannot be assigned to issues of this repository",
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Maintainer unassigns other users",
+			commenter:       bob,
+			comment:         "/unassign @alice",
+			assignees:       []*github.User{alice},
+			expectAssignees: nil,
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Contributor cannot assign other users",
+			commenter:       alice,
+			comment:         "/assign @bob",
+			expectAssignees: nil,
+			expectComment:   "@alice only the users with the `triage` role in this repository can assign other users.",
+		},
+		{
+			caseName:        "Contributor cannot assign other users but can unassign themselves",
+			commenter:       alice,
+			comment:         "/assign @bob\n/unassign @bob\n/unassign",
+			assignees:       []*github.User{alice},
+			expectAssignees: nil,
+			expectComment:   "@alice only the users with the `triage` role in this repository can assign other users.",
+		},
+		{
+			caseName: "Maintainer without the override role is limited by the policy",
+			config: func(cfg *config.AssignConfig) {
+				cfg.OverrideRole = permission.RoleMaintain
+			},
+			commenter:       bob,
+			comment:         "/assign @alice",
+			assignees:       []*github.User{carol},
+			expectAssignees: []string{"carol"},
+			expectComment:   "- @alice: this issue is already assigned to @carol",
+		},
+		{
+			caseName:        "Maintainer with the override role is not limited by the policy",
+			commenter:       bob,
+			comment:         "/assign @alice",
+			assignees:       []*github.User{carol},
+			expectAssignees: []string{"carol", "alice"},
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Assign and unassign the commenter",
+			commenter:       alice,
+			comment:         "/assign\n/unassign",
+			expectAssignees: nil,
+			expectReaction:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Collaborators["alice"] = "read"
+			gh.Collaborators["bob"] = "triage"
+			gh.Issues[1] = &github.Issue{
+				Number:    github.Ptr(1),
+				State:     github.Ptr("open"),
+				Assignees: tc.assignees,
+				Labels:    []*github.Label{{Name: github.Ptr(actors.HelpWantedLabel)}},
+			}
+			ghClient := gh.Client()
+
+			cfg := config.Default().Actors.Assign
+			if tc.config != nil {
+				tc.config(&cfg)
+			}
+			assignActor := &actor{
+				ghClient: ghClient,
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config:      cfg,
+				permissions: permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+			}
+			// the issue of the event is a snapshot that is not updated by the commands
+			issue := *gh.Issues[1]
+			captured := assignActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr(tc.comment), User: tc.commenter},
+					Issue:   &issue,
+					Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+			assert.NoError(t, assignActor.Handler())
+
+			assert.Equal(t, tc.expectAssignees, gh.AssigneeLogins(1))
+			if len(tc.expectComment) != 0 {
+				assert.Len(t, gh.Comments[1], 1)
+				assert.Contains(t, gh.CommentBodies(1)[0], tc.expectComment)
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+				// the issue is no longer looking for a contributor once assigned
+				if len(gh.AssigneeLogins(1)) != 0 {
+					assert.Empty(t, gh.LabelNames(1))
+				}
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+const (
+	ccActorName = "CCActor"
+
+	ccCommand   = "cc"
+	unccCommand = "uncc"
+)
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
+}
+
+// reviewers are the targets of a '/[un]cc' command.
+type reviewers struct {
+	// users are the logins of the users.
+	users []string
+	// teams are the slugs of the teams, which are referred to as '@org/team'.
+	teams []string
+}
+
+func NewCCActor(ghClient *actors.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+	return &actor{
+		ghClient: ghClient,
+		logger:   logger,
+	}
+}
+
+func (a *actor) Handler() error {
+	var (
+		issue     = a.event.GetIssue()
+		repo      = a.event.GetRepo()
+		comment   = a.event.GetComment()
+		loginUser = comment.GetUser().GetLogin()
+		failures  []string
+		succeeded bool
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), issue.GetNumber())
+
+	for _, command := range a.commands {
+		targets := parseReviewers(command.Args)
+		// a command without targets refers to the commenter, as in Prow
+		if len(targets.users) == 0 && len(targets.teams) == 0 {
+			targets.users = []string{loginUser}
+		}
+
+		var failed []string
+		if command.Name == ccCommand {
+			failed = a.requestReviewers(repo.GetFullName(), issue.GetNumber(), targets)
+		} else {
+			failed = a.removeReviewers(repo.GetFullName(), issue.GetNumber(), targets)
+		}
+		failures = append(failures, failed...)
+		succeeded = succeeded || len(failed) < len(targets.users)+len(targets.teams)
+	}
+
+	if succeeded {
+		if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+			return err
+		}
+		a.logger.Infof("add a reaction '%s' to comment %d of pr #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+	}
+
+	if len(failures) != 0 {
+		return actors.AddComment(
+			a.ghClient,
+			fmt.Sprintf("@%s the following review requests could not be updated:\n\n%s", loginUser, strings.Join(failures, "\n")),
+			repo.GetFullName(),
+			issue.GetNumber(),
+		)
+	}
+
+	return nil
+}
+
+// requestReviewers requests reviews from the targets that are collaborators of the
+// repository and returns a description of each failed request.
+func (a *actor) requestReviewers(repoFullName string, number int, targets reviewers) []string {
+	owner, repoName := actors.GetOwnerRepo(repoFullName)
+
+	var (
+		failed []string
+		valid  reviewers
+	)
+	for _, user := range targets.users {
+		isCollaborator, _, err := a.ghClient.Repositories.IsCollaborator(a.ghClient.Context(), owner, repoName, user)
+		switch {
+		case err != nil:
+			failed = append(failed, fmt.Sprintf("- @%s: %v", user, err))
+		case !isCollaborator:
+			failed = append(failed, fmt.Sprintf("- @%s: not a collaborator of this repository", user))
+		default:
+			valid.users = append(valid.users, user)
+		}
+	}
+	valid.teams = targets.teams
+	if len(valid.users) == 0 && len(valid.teams) == 0 {
+		return failed
+	}
+
+	if _, _, err := a.ghClient.PullRequests.RequestReviewers(
+		a.ghClient.Context(),
+		owner,
+		repoName,
+		number,
+		github.ReviewersRequest{
+			Reviewers:     valid.users,
+			TeamReviewers: valid.teams,
+		},
+	); err != nil {
+		a.logger.Errorf("failed to request reviewers %v by err: %v", valid, err)
+		return append(failed, valid.describe(err)...)
+	}
+	a.logger.Infof("requested reviewers %v on pr #%d", valid, number)
+
+	return failed
+}
+
+// removeReviewers withdraws the review requests of the targets and returns a description of each failed request.
+func (a *actor) removeReviewers(repoFullName string, number int, targets reviewers) []string {
+	owner, repoName := actors.GetOwnerRepo(repoFullName)
+	if _, err := a.ghClient.PullRequests.RemoveReviewers(
+		a.ghClient.Context(),
+		owner,
+		repoName,
+		number,
+		github.ReviewersRequest{
+			Reviewers:     targets.users,
+			TeamReviewers: targets.teams,
+		},
+	); err != nil {
+		a.logger.Errorf("failed to remove reviewers %v by err: %v", targets, err)
+		return targets.describe(err)
+	}
+	a.logger.Infof("removed reviewers %v from pr #%d", targets, number)
+
+	return nil
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	genericEvent := event.Event
+	commentEvent, ok := genericEvent.(github.IssueCommentEvent)
+	if !ok {
+		a.logger.Error("cannot extract event to github.IssueCommentEvent, please check event type")
+		return false
+	}
+
+	if !commentEvent.Issue.IsPullRequest() || len(commentEvent.Comment.GetBody()) == 0 {
+		return false
+	}
+	if commentEvent.Issue.GetClosedBy() != nil || !commentEvent.Issue.GetClosedAt().IsZero() {
+		return false
+	}
+
+	commands := actors.ParseCommands(commentEvent.Comment.GetBody(), ccCommand, unccCommand)
+	if commands == nil {
+		return false
+	}
+	a.event = commentEvent
+	a.commands = commands
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return ccActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         ccCommand,
+			Syntax:       "/cc @user...",
+			Description:  "Requests the review of the pull request from the users.",
+			PullRequests: true,
+		},
+		{
+			Name:         unccCommand,
+			Syntax:       "/uncc @user...",
+			Description:  "Removes the review requests of the users.",
+			PullRequests: true,
+		},
+	}
+}
+
+// parseReviewers parses the '@user' and '@org/team' arguments of a command,
+// arguments may also be separated by commas.
+func parseReviewers(args []string) reviewers {
+	var ret reviewers
+	for _, arg := range args {
+		for _, target := range strings.Split(arg, ",") {
+			target = strings.TrimPrefix(strings.TrimSpace(target), "@")
+			if len(target) == 0 {
+				continue
+			}
+
+			if _, team, ok := strings.Cut(target, "/"); ok {
+				if len(team) != 0 && !slices.Contains(ret.teams, team) {
+					ret.teams = append(ret.teams, team)
+				}
+			} else if !slices.Contains(ret.users, target) {
+				ret.users = append(ret.users, target)
+			}
+		}
+	}
+
+	return ret
+}
+
+// describe returns a failure description of every target.
+func (r reviewers) describe(err error) []string {
+	var ret []string
+	for _, user := range r.users {
+		ret = append(ret, fmt.Sprintf("- @%s: %v", user, err))
+	}
+	for _, team := range r.teams {
+		ret = append(ret, fmt.Sprintf("- team %s: %v", team, err))
+	}
+
+	return ret
+}
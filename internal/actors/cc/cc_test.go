@@ -0,0 +1,248 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cc
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+)
+
+func TestParseReviewers(t *testing.T) {
+	cases := []struct {
+		caseName string
+		comment  string
+		expect   reviewers
+	}{
+		{
+			caseName: "Parse users",
+			comment:  "/cc @alice @bob",
+			expect:   reviewers{users: []string{"alice", "bob"}},
+		},
+		{
+			caseName: "Parse teams",
+			comment:  "/cc @alice @example-org/maintainers",
+			expect: reviewers{
+				users: []string{"alice"},
+				teams: []string{"maintainers"},
+			},
+		},
+		{
+			caseName: "Parse comma separated users without at sign",
+			comment:  "/uncc alice,@bob, alice",
+			expect:   reviewers{users: []string{"alice", "bob"}},
+		},
+		{
+			caseName: "Parse command without targets",
+			comment:  "/cc",
+			expect:   reviewers{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			commands := actors.ParseCommands(tc.comment, ccCommand, unccCommand)
+			assert.Len(t, commands, 1)
+			assert.Equal(t, tc.expect, parseReviewers(commands[0].Args))
+		})
+	}
+}
+
+func TestCCCapture(t *testing.T) {
+	pullRequestLinks := &github.PullRequestLinks{
+		URL: github.Ptr("https://github.com/example_owner/example_repo/pull/1234567890"),
+	}
+
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "cc actor capture cc command on pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/cc @alice"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: pullRequestLinks,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "cc actor capture uncc command on pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/uncc @alice"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: pullRequestLinks,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "cc actor does not capture issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/cc @alice"),
+					},
+					Issue: &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "cc actor does not capture unmatched command",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/ccc @alice"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: pullRequestLinks,
+					},
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			ccActor := &actor{
+				// a noop logger for testing only
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			assert.Equal(t, tc.expect, ccActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestCCHandler(t *testing.T) {
+	cases := []struct {
+		caseName        string
+		comment         string
+		requested       []string
+		err             error
+		expectRequested []string
+		expectComment   string
+		expectReaction  bool
+	}{
+		{
+			caseName:        "Request the review of the commenter",
+			comment:         "/cc",
+			expectRequested: []string{"alice"},
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Request the review of other users",
+			comment:         "/cc @bob",
+			expectRequested: []string{"bob"},
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Remove the review request",
+			comment:         "/uncc @bob",
+			requested:       []string{"alice", "bob"},
+			expectRequested: []string{"alice"},
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Reject the users that are not collaborators",
+			comment:         "/cc @bob @mallory",
+			expectRequested: []string{"bob"},
+			expectComment:   "@alice the following review requests could not be updated:\n\n- @mallory: not a collaborator of this repository",
+			expectReaction:  true,
+		},
+		{
+			caseName:        "Reply the failed review requests",
+			comment:         "/cc @bob",
+			err:             errors.New("review cannot be requested from pull request author"),
+			expectRequested: nil,
+			expectComment:   "@alice the following review requests could not be updated:\n\n- @bob: review cannot be requested from pull request author",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Collaborators["alice"] = "write"
+			gh.Collaborators["bob"] = "read"
+			gh.Errors["PullRequests.RequestReviewers"] = tc.err
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr("open"),
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+			}
+			gh.PullRequests[1] = &github.PullRequest{Number: github.Ptr(1), State: github.Ptr("open")}
+			for _, login := range tc.requested {
+				gh.PullRequests[1].RequestedReviewers = append(gh.PullRequests[1].RequestedReviewers, &github.User{Login: github.Ptr(login)})
+			}
+
+			ccActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			captured := ccActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						ID:   github.Ptr(int64(100)),
+						Body: github.Ptr(tc.comment),
+						User: &github.User{Login: github.Ptr("alice")},
+					},
+					Issue: gh.Issues[1],
+					Repo:  &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+			assert.NoError(t, ccActor.Handler())
+
+			var requested []string
+			for _, user := range gh.PullRequests[1].RequestedReviewers {
+				requested = append(requested, user.GetLogin())
+			}
+			assert.Equal(t, tc.expectRequested, requested)
+			if len(tc.expectComment) != 0 {
+				assert.Equal(t, []string{tc.expectComment}, gh.CommentBodies(1))
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+const (
+	claimActorName = "ClaimActor"
+
+	assignCommand = "assign"
+
+	// pingMarker marks the comments asking the assignee whether they are
+	// still working on the issue, it is formatted with the login of the assignee.
+	pingMarker = "<!-- actbot:claim-ping @%s -->"
+
+	day = 24 * time.Hour
+)
+
+// action is what the actor does with the claim of an assignee.
+type action int
+
+const (
+	keepClaim action = iota
+	pingAssignee
+	expireClaim
+)
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+	config   config.ClaimConfig
+
+	// repoFullName is the repository running the workflow,
+	// the 'schedule' event does not contain it.
+	repoFullName string
+
+	// now returns the current time, tests replace it to control the claim durations.
+	now func() time.Time
+}
+
+func NewClaimActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient:     ghClient,
+		logger:       logger,
+		config:       opts.Config.Actors.Claim,
+		repoFullName: opts.Repository,
+		now:          time.Now,
+	}
+}
+
+func (a *actor) Handler() error {
+	a.logger.Infof("actor %s started processing events, repository: %s", a.Name(), a.repoFullName)
+
+	issues, err := actors.ListOpenIssuesByAssignee(a.ghClient, a.repoFullName, "*")
+	if err != nil {
+		return err
+	}
+
+	// an issue failing does not prevent the claims of the other issues from expiring
+	var errs *multierror.Error
+	for _, issue := range issues {
+		if err := a.handleIssue(issue); err != nil {
+			a.logger.Errorf("failed to check the claims of issue #%d by err: %v", issue.GetNumber(), err)
+			errs = multierror.Append(errs, fmt.Errorf("issue #%d: %w", issue.GetNumber(), err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// handleIssue pings the inactive assignees of the issue and unassigns the ones
+// that did not answer within the grace period.
+func (a *actor) handleIssue(issue *github.Issue) error {
+	comments, err := actors.ListComments(a.ghClient, a.repoFullName, issue.GetNumber())
+	if err != nil {
+		return err
+	}
+
+	timeline, err := actors.ListTimeline(a.ghClient, a.repoFullName, issue.GetNumber())
+	if err != nil {
+		return err
+	}
+
+	var (
+		assignees = issue.Assignees
+		expired   int
+	)
+	for _, assignee := range assignees {
+		login := assignee.GetLogin()
+		next := a.decide(comments, timeline, login)
+		if next == keepClaim {
+			continue
+		}
+
+		// an open pull request of the assignee referencing the issue is the sign of an ongoing work
+		if hasOpenPR(timeline, login) {
+			continue
+		}
+
+		if next == pingAssignee {
+			err = a.ping(issue.GetNumber(), login)
+		} else {
+			err = a.expire(issue.GetNumber(), login)
+			expired++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the issue is open to other contributors again once nobody works on it
+	if expired != 0 && expired == len(assignees) {
+		if err := actors.AddLabelToIssue(a.ghClient, a.repoFullName, issue.GetNumber(), actors.HelpWantedLabel); err != nil {
+			return err
+		}
+		a.logger.Infof("add '%s' label to issue #%d", actors.HelpWantedLabel, issue.GetNumber())
+	}
+
+	return nil
+}
+
+// decide returns what to do with the claim of the assignee according to the comments and the timeline
+// of the issue, assignees that did not claim the issue with '/assign' are left alone. Assigning the user
+// again, such as through the UI after the claim expired, counts as an activity and restarts the claim.
+func (a *actor) decide(comments []*github.IssueComment, timeline []*github.Timeline, login string) action {
+	var (
+		marker       = fmt.Sprintf(pingMarker, login)
+		claimed      bool
+		lastActivity time.Time
+		lastPing     time.Time
+	)
+	for _, comment := range comments {
+		createdAt := comment.GetCreatedAt().Time
+		switch {
+		case actors.IsBotComment(a.ghClient, comment) && strings.Contains(comment.GetBody(), marker):
+			lastPing = latest(lastPing, createdAt)
+		case isClaim(comment, login):
+			claimed = true
+			lastActivity = latest(lastActivity, createdAt)
+		case strings.EqualFold(comment.GetUser().GetLogin(), login):
+			lastActivity = latest(lastActivity, createdAt)
+		}
+	}
+	for _, event := range timeline {
+		if event.GetEvent() == "assigned" && strings.EqualFold(event.GetAssignee().GetLogin(), login) {
+			lastActivity = latest(lastActivity, event.GetCreatedAt().Time)
+		}
+	}
+	if !claimed {
+		return keepClaim
+	}
+
+	now := a.now()
+	if lastPing.After(lastActivity) {
+		if now.Sub(lastPing) >= time.Duration(a.config.GraceDays)*day {
+			return expireClaim
+		}
+		return keepClaim
+	}
+	if now.Sub(lastActivity) >= time.Duration(a.config.InactiveDays)*day {
+		return pingAssignee
+	}
+
+	return keepClaim
+}
+
+func (a *actor) ping(number int, login string) error {
+	content := fmt.Sprintf(
+		"%s\n@%s are you still working on this issue? There has been no activity for %d days, please leave a comment "+
+			"or it will be unassigned in %d days so that other contributors can pick it up.",
+		fmt.Sprintf(pingMarker, login), login, a.config.InactiveDays, a.config.GraceDays,
+	)
+	if err := actors.AddComment(a.ghClient, content, a.repoFullName, number); err != nil {
+		return err
+	}
+	a.logger.Infof("pinged inactive assignee '%s' of issue #%d", login, number)
+
+	return nil
+}
+
+func (a *actor) expire(number int, login string) error {
+	owner, repoName := actors.GetOwnerRepo(a.repoFullName)
+	if _, _, err := a.ghClient.Issues.RemoveAssignees(a.ghClient.Context(), owner, repoName, number, []string{login}); err != nil {
+		return err
+	}
+	a.logger.Infof("unassigned inactive assignee '%s' of issue #%d", login, number)
+
+	content := fmt.Sprintf(
+		"@%s this issue has been unassigned since there was no answer within %d days, feel free to `/assign` it again if you are still working on it.",
+		login, a.config.GraceDays,
+	)
+
+	return actors.AddComment(a.ghClient, content, a.repoFullName, number)
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	if _, ok := event.Event.(actors.ScheduleEvent); !ok {
+		a.logger.Error("cannot extract event to actors.ScheduleEvent, please check event type")
+		return false
+	}
+	if len(a.repoFullName) == 0 {
+		a.logger.Error("cannot find the repository of the 'schedule' event")
+		return false
+	}
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return claimActorName
+}
+
+// isClaim reports whether the comment assigns the issue to the user,
+// either with a '/assign' of the user or a '/assign @user' of someone else.
+func isClaim(comment *github.IssueComment, login string) bool {
+	for _, command := range actors.ParseCommands(comment.GetBody(), assignCommand) {
+		if len(command.Args) == 0 && strings.EqualFold(comment.GetUser().GetLogin(), login) {
+			return true
+		}
+		for _, arg := range command.Args {
+			for _, target := range strings.Split(arg, ",") {
+				if strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(target), "@"), login) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// hasOpenPR reports whether the timeline references an open pull request of the user.
+func hasOpenPR(timeline []*github.Timeline, login string) bool {
+	for _, event := range timeline {
+		if event.GetEvent() != "cross-referenced" {
+			continue
+		}
+		source := event.GetSource().GetIssue()
+		if source.IsPullRequest() && source.GetState() == "open" && strings.EqualFold(source.GetUser().GetLogin(), login) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,385 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claim
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+var now = time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+func comment(login, body string, daysAgo int) *github.IssueComment {
+	user := &github.User{Login: github.Ptr(login), Type: github.Ptr("User")}
+	if login == actors.DefaultBotLogin {
+		user.Type = github.Ptr("Bot")
+	}
+	return &github.IssueComment{
+		User:      user,
+		Body:      github.Ptr(body),
+		CreatedAt: &github.Timestamp{Time: now.Add(-time.Duration(daysAgo) * day)},
+	}
+}
+
+func assigned(login string, daysAgo int) *github.Timeline {
+	return &github.Timeline{
+		Event:     github.Ptr("assigned"),
+		Assignee:  &github.User{Login: github.Ptr(login)},
+		CreatedAt: &github.Timestamp{Time: now.Add(-time.Duration(daysAgo) * day)},
+	}
+}
+
+func TestClaimDecide(t *testing.T) {
+	cases := []struct {
+		caseName string
+		login    string
+		comments []*github.IssueComment
+		timeline []*github.Timeline
+		expect   action
+	}{
+		{
+			caseName: "Keep assignee that did not claim the issue",
+			comments: []*github.IssueComment{
+				comment("alice", "I am looking into it", 30),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Keep recent claim",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 3),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Ping inactive assignee",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 20),
+			},
+			expect: pingAssignee,
+		},
+		{
+			caseName: "Ping inactive assignee assigned by a maintainer",
+			comments: []*github.IssueComment{
+				comment("bob", "/assign @alice", 20),
+			},
+			expect: pingAssignee,
+		},
+		{
+			caseName: "Keep assignee with recent activity",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 20),
+				comment("alice", "Still on it", 2),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Keep pinged assignee within the grace period",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 20),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 3),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Expire pinged assignee after the grace period",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+			},
+			expect: expireClaim,
+		},
+		{
+			caseName: "Keep pinged assignee that answered",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+				comment("alice", "Yes, sorry for the delay", 7),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Ping marker in the comment of a user does not count",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment("mallory", "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+			},
+			expect: pingAssignee,
+		},
+		{
+			caseName: "Ping of another assignee does not count",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 10),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @bob -->\n@bob are you still working on this issue?", 8),
+			},
+			expect: keepClaim,
+		},
+		{
+			caseName: "Expire assignee pinged by the user behind a personal access token",
+			login:    "actbot-user",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment("actbot-user", "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+			},
+			expect: expireClaim,
+		},
+		{
+			caseName: "Keep assignee reassigned after the expiry",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 40),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 26),
+			},
+			timeline: []*github.Timeline{assigned("alice", 40), assigned("alice", 5)},
+			expect:   keepClaim,
+		},
+		{
+			caseName: "Ping inactive assignee reassigned after the expiry",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 60),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 46),
+			},
+			timeline: []*github.Timeline{assigned("alice", 60), assigned("alice", 20)},
+			expect:   pingAssignee,
+		},
+		{
+			caseName: "Assignment of another user does not count",
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+			},
+			timeline: []*github.Timeline{assigned("bob", 2)},
+			expect:   expireClaim,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			if len(tc.login) != 0 {
+				gh.Login = tc.login
+			}
+			claimActor := &actor{
+				ghClient: gh.Client(),
+				config:   config.Default().Actors.Claim,
+				now:      func() time.Time { return now },
+			}
+			assert.Equal(t, tc.expect, claimActor.decide(tc.comments, tc.timeline, "alice"))
+		})
+	}
+}
+
+func TestHasOpenPR(t *testing.T) {
+	crossReference := func(login, state string, pullRequest bool) *github.Timeline {
+		issue := &github.Issue{
+			State: github.Ptr(state),
+			User:  &github.User{Login: github.Ptr(login)},
+		}
+		if pullRequest {
+			issue.PullRequestLinks = &github.PullRequestLinks{}
+		}
+		return &github.Timeline{
+			Event:  github.Ptr("cross-referenced"),
+			Source: &github.Source{Issue: issue},
+		}
+	}
+
+	cases := []struct {
+		caseName string
+		timeline []*github.Timeline
+		expect   bool
+	}{
+		{
+			caseName: "Open pull request of the assignee",
+			timeline: []*github.Timeline{crossReference("alice", "open", true)},
+			expect:   true,
+		},
+		{
+			caseName: "Closed pull request of the assignee",
+			timeline: []*github.Timeline{crossReference("alice", "closed", true)},
+			expect:   false,
+		},
+		{
+			caseName: "Open pull request of someone else",
+			timeline: []*github.Timeline{crossReference("bob", "open", true)},
+			expect:   false,
+		},
+		{
+			caseName: "Issue of the assignee",
+			timeline: []*github.Timeline{crossReference("alice", "open", false)},
+			expect:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, hasOpenPR(tc.timeline, "alice"))
+		})
+	}
+}
+
+func TestClaimCapture(t *testing.T) {
+	cases := []struct {
+		caseName     string
+		repoFullName string
+		event        actors.GenericEvent
+		expect       bool
+	}{
+		{
+			caseName:     "Capture schedule event",
+			repoFullName: "owner/repo",
+			event:        actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}},
+			expect:       true,
+		},
+		{
+			caseName: "Do not capture schedule event without repository",
+			event:    actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}},
+			expect:   false,
+		},
+		{
+			caseName:     "Do not capture other events",
+			repoFullName: "owner/repo",
+			event:        actors.GenericEvent{Event: github.IssueCommentEvent{}},
+			expect:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			claimActor := &actor{
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				repoFullName: tc.repoFullName,
+			}
+			assert.Equal(t, tc.expect, claimActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestClaimHandler(t *testing.T) {
+	cases := []struct {
+		caseName        string
+		assignees       []string
+		comments        []*github.IssueComment
+		timeline        []*github.Timeline
+		expectAssignees []string
+		expectLabels    []string
+		expectComment   string
+	}{
+		{
+			caseName:        "Ping the inactive assignee",
+			assignees:       []string{"alice"},
+			comments:        []*github.IssueComment{comment("alice", "/assign", 20)},
+			expectAssignees: []string{"alice"},
+			expectComment:   "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?",
+		},
+		{
+			caseName:  "Unassign the expired claim",
+			assignees: []string{"alice"},
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+			},
+			expectAssignees: nil,
+			expectLabels:    []string{actors.HelpWantedLabel},
+			expectComment:   "@alice this issue has been unassigned since there was no answer within 7 days",
+		},
+		{
+			caseName:  "Keep the issue assigned to another active assignee",
+			assignees: []string{"alice", "bob"},
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 30),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 8),
+				comment("bob", "/assign", 1),
+			},
+			expectAssignees: []string{"bob"},
+			expectComment:   "@alice this issue has been unassigned since there was no answer within 7 days",
+		},
+		{
+			caseName:  "Keep the claim of the assignee with an open pull request",
+			assignees: []string{"alice"},
+			comments:  []*github.IssueComment{comment("alice", "/assign", 20)},
+			timeline: []*github.Timeline{
+				{
+					Event: github.Ptr("cross-referenced"),
+					Source: &github.Source{Issue: &github.Issue{
+						State:            github.Ptr("open"),
+						User:             &github.User{Login: github.Ptr("alice")},
+						PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/2")},
+					}},
+				},
+			},
+			expectAssignees: []string{"alice"},
+		},
+		{
+			caseName:  "Ping the assignee reassigned after the expiry instead of unassigning them",
+			assignees: []string{"alice"},
+			comments: []*github.IssueComment{
+				comment("alice", "/assign", 60),
+				comment(actors.DefaultBotLogin, "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?", 46),
+				comment(actors.DefaultBotLogin, "@alice this issue has been unassigned since there was no answer within 7 days", 39),
+			},
+			timeline:        []*github.Timeline{assigned("alice", 60), assigned("alice", 20)},
+			expectAssignees: []string{"alice"},
+			expectComment:   "<!-- actbot:claim-ping @alice -->\n@alice are you still working on this issue?",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			issue := &github.Issue{Number: github.Ptr(1), State: github.Ptr("open")}
+			for _, login := range tc.assignees {
+				issue.Assignees = append(issue.Assignees, &github.User{Login: github.Ptr(login)})
+			}
+			gh.Issues[1] = issue
+			gh.Comments[1] = tc.comments
+			gh.Timelines[1] = tc.timeline
+
+			claimActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config:       config.Default().Actors.Claim,
+				repoFullName: "owner/repo",
+				now:          func() time.Time { return now },
+			}
+			assert.True(t, claimActor.Capture(actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}}))
+			assert.NoError(t, claimActor.Handler())
+
+			assert.Equal(t, tc.expectAssignees, gh.AssigneeLogins(1))
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			bodies := gh.CommentBodies(1)
+			if len(tc.expectComment) != 0 {
+				if assert.Len(t, bodies, len(tc.comments)+1) {
+					assert.Contains(t, bodies[len(bodies)-1], tc.expectComment)
+				}
+			} else {
+				assert.Len(t, bodies, len(tc.comments))
+			}
+		})
+	}
+}
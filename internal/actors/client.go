@@ -0,0 +1,160 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors
+
+import (
+	"context"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// Client is the part of the GitHub API used by the actors, the services mirror
+// the go-github ones so that the actors can be tested without the network.
+type Client struct {
+	Issues       IssuesService
+	Reactions    ReactionsService
+	Checks       ChecksService
+	Actions      ActionsService
+	PullRequests PullRequestsService
+	Repositories RepositoriesService
+	Search       SearchService
+	Git          GitService
+	Users        UsersService
+
+	// ctx is the context of the calls, nil for the background context.
+	ctx context.Context
+
+	// botLogin is the login of the user behind the token, empty for DefaultBotLogin.
+	botLogin string
+}
+
+// WithContext returns a copy of the client making the calls with the context,
+// such as a context carrying the logger of the actor making them.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+
+	return &clone
+}
+
+// Context returns the context of the calls made with the client.
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// WithBotLogin returns a copy of the client authenticated as the user with the login,
+// the comments of that user are trusted as the comments of the bot.
+func (c *Client) WithBotLogin(login string) *Client {
+	clone := *c
+	clone.botLogin = login
+
+	return &clone
+}
+
+// BotLogin returns the login of the user behind the token of the client.
+func (c *Client) BotLogin() string {
+	if len(c.botLogin) == 0 {
+		return DefaultBotLogin
+	}
+
+	return c.botLogin
+}
+
+// NewClient returns a Client calling the GitHub API with the go-github client.
+func NewClient(ghClient *github.Client) *Client {
+	return &Client{
+		Issues:       ghClient.Issues,
+		Reactions:    ghClient.Reactions,
+		Checks:       ghClient.Checks,
+		Actions:      ghClient.Actions,
+		PullRequests: ghClient.PullRequests,
+		Repositories: ghClient.Repositories,
+		Search:       ghClient.Search,
+		Git:          ghClient.Git,
+		Users:        ghClient.Users,
+	}
+}
+
+// IssuesService manages issues, their comments, labels and assignees.
+type IssuesService interface {
+	Get(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error)
+	Edit(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	ListByRepo(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	ListIssueTimeline(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Timeline, *github.Response, error)
+
+	CreateComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	ListComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+
+	ListLabels(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+	ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+	AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
+	RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error)
+
+	IsAssignee(ctx context.Context, owner, repo, user string) (bool, *github.Response, error)
+	AddAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*github.Issue, *github.Response, error)
+	RemoveAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*github.Issue, *github.Response, error)
+}
+
+// ReactionsService reacts to comments.
+type ReactionsService interface {
+	CreateIssueCommentReaction(ctx context.Context, owner, repo string, id int64, content string) (*github.Reaction, *github.Response, error)
+}
+
+// ChecksService lists the check runs of commits.
+type ChecksService interface {
+	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+}
+
+// ActionsService manages the GitHub Actions jobs.
+type ActionsService interface {
+	RerunJobByID(ctx context.Context, owner, repo string, jobID int64) (*github.Response, error)
+}
+
+// PullRequestsService manages pull requests and their reviews.
+type PullRequestsService interface {
+	Get(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error)
+	RemoveReviewers(ctx context.Context, owner, repo string, number int, reviewers github.ReviewersRequest) (*github.Response, error)
+	CreateReview(ctx context.Context, owner, repo string, number int, review *github.PullRequestReviewRequest) (*github.PullRequestReview, *github.Response, error)
+}
+
+// RepositoriesService reads the repository contents and collaborators and publishes commit statuses.
+type RepositoriesService interface {
+	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error)
+	IsCollaborator(ctx context.Context, owner, repo, user string) (bool, *github.Response, error)
+	GetPermissionLevel(ctx context.Context, owner, repo, user string) (*github.RepositoryPermissionLevel, *github.Response, error)
+}
+
+// SearchService searches issues and pull requests.
+type SearchService interface {
+	Issues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error)
+}
+
+// GitService reads the git database of the repository.
+type GitService interface {
+	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, *github.Response, error)
+}
+
+// UsersService reads the users.
+type UsersService interface {
+	Get(ctx context.Context, user string) (*github.User, *github.Response, error)
+}
@@ -0,0 +1,125 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// commandRegexp matches a single slash command line, such as "/area core runtime".
+	commandRegexp = regexp.MustCompile(`^/([a-zA-Z][\w-]*)(?:\s+(.*))?$`)
+
+	// htmlCommentRegexp matches HTML comments, an unterminated comment runs to the end of the body.
+	htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?(?:-->|$)`)
+)
+
+// Command is a slash command extracted from a comment body.
+type Command struct {
+	// Name is the command name without the leading slash, in lower case, e.g. "area".
+	Name string
+
+	// Args are the whitespace separated arguments following the command name.
+	Args []string
+}
+
+// String returns the command as written in a comment, such as "/area core runtime".
+func (c Command) String() string {
+	return strings.Join(append([]string{"/" + c.Name}, c.Args...), " ")
+}
+
+// ParseCommands extracts the slash commands of a comment body, one command per line,
+// and returns those named after any of the given names in the order they appear.
+// If no names are given, all commands are returned.
+// Commands quoted from other comments or written as examples, that is, inside
+// code blocks, blockquotes and HTML comments, are not taken into account.
+func ParseCommands(body string, names ...string) []Command {
+	var commands []Command
+	for _, line := range commandLines(body) {
+		match := commandRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		name := strings.ToLower(match[1])
+		if len(names) != 0 && !slices.Contains(names, name) {
+			continue
+		}
+		command := Command{Name: name}
+		if args := strings.Fields(match[2]); len(args) != 0 {
+			command.Args = args
+		}
+		commands = append(commands, command)
+	}
+
+	return commands
+}
+
+// commandLines returns the trimmed lines of the comment body that may hold a command,
+// skipping the Markdown fenced and indented code blocks, blockquotes and HTML comments.
+func commandLines(body string) []string {
+	var (
+		lines []string
+		// fence is the opening code fence, such as "```", when inside a fenced code block.
+		fence string
+	)
+
+	body = htmlCommentRegexp.ReplaceAllString(body, "")
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if len(fence) != 0 {
+			// a closing fence is made of at least as many fence characters as the opening one
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if marker := codeFence(trimmed); len(marker) != 0 {
+			fence = marker
+			continue
+		}
+
+		// indented code blocks and blockquotes
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+
+	return lines
+}
+
+// codeFence returns the opening code fence of the line, such as "```" or "~~~~",
+// or an empty string if the line does not open a fenced code block. The fence may only
+// be followed by an info string, which cannot contain backticks after a backtick fence,
+// so that an inline code span such as "```x```" does not open a block.
+func codeFence(line string) string {
+	for _, char := range []string{"`", "~"} {
+		fence := line[:len(line)-len(strings.TrimLeft(line, char))]
+		if len(fence) < 3 {
+			continue
+		}
+		if char == "`" && strings.Contains(line[len(fence):], char) {
+			return ""
+		}
+		return fence
+	}
+
+	return ""
+}
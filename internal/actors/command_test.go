@@ -0,0 +1,213 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommands(t *testing.T) {
+	cases := []struct {
+		caseName string
+		body     string
+		names    []string
+		expect   []Command
+	}{
+		{
+			caseName: "Parse a single command",
+			body:     "/assign",
+			expect: []Command{
+				{Name: "assign"},
+			},
+		},
+		{
+			caseName: "Parse command arguments",
+			body:     "/area  core   runtime ",
+			expect: []Command{
+				{Name: "area", Args: []string{"core", "runtime"}},
+			},
+		},
+		{
+			caseName: "Parse one command per line",
+			body:     "/kind bug\r\n/area core\n/assign",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+				{Name: "area", Args: []string{"core"}},
+				{Name: "assign"},
+			},
+		},
+		{
+			caseName: "Ignore text around commands",
+			body:     "Thanks for the report!\n  /kind bug\nPlease take a look /area core",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Only return the named commands",
+			body:     "/kind bug\n/area core\n/unarea docs",
+			names:    []string{"area", "unarea"},
+			expect: []Command{
+				{Name: "area", Args: []string{"core"}},
+				{Name: "unarea", Args: []string{"docs"}},
+			},
+		},
+		{
+			caseName: "Command names are case insensitive",
+			body:     "/LGTM",
+			names:    []string{"lgtm"},
+			expect: []Command{
+				{Name: "lgtm"},
+			},
+		},
+		{
+			caseName: "Do not match similar command names",
+			body:     "/foo_assign\n/retest1",
+			names:    []string{"assign", "retest"},
+			expect:   nil,
+		},
+		{
+			caseName: "No commands",
+			body:     "LGTM, thanks! / nothing here",
+			expect:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, ParseCommands(tc.body, tc.names...))
+		})
+	}
+}
+
+func TestParseCommandsSkipNonCommandText(t *testing.T) {
+	cases := []struct {
+		caseName string
+		body     string
+		expect   []Command
+	}{
+		{
+			caseName: "Skip commands in fenced code blocks",
+			body:     "Run:\n```\n/assign\n```\n/kind bug",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Skip commands in fenced code blocks with info string",
+			body:     "```markdown\n/assign\n```",
+			expect:   nil,
+		},
+		{
+			caseName: "An inline code span does not open a fenced code block",
+			body:     "```/assign```\n/kind bug",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Skip commands in tilde fenced code blocks",
+			body:     "~~~\n/assign\n~~~\n/retest",
+			expect: []Command{
+				{Name: "retest"},
+			},
+		},
+		{
+			caseName: "A code block is only closed by the same fence",
+			body:     "````\n```\n/assign\n```\n/lgtm\n````\n/retest",
+			expect: []Command{
+				{Name: "retest"},
+			},
+		},
+		{
+			caseName: "Skip commands in unterminated fenced code blocks",
+			body:     "/retest\n```\n/assign",
+			expect: []Command{
+				{Name: "retest"},
+			},
+		},
+		{
+			caseName: "Skip commands in indented code blocks",
+			body:     "Example:\n\n    /assign\n\t/unassign",
+			expect:   nil,
+		},
+		{
+			caseName: "Skip commands in blockquotes",
+			body:     "> /assign\n>> /area core\n  > /kind bug\nI will take it",
+			expect:   nil,
+		},
+		{
+			caseName: "Skip commands in HTML comments",
+			body:     "<!-- /assign -->\n/kind bug",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Skip commands in multi-line HTML comments",
+			body:     "<!--\n/assign\n/area core\n-->\n/kind bug",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Keep commands following an inline HTML comment",
+			body:     "<!-- template --> /kind bug",
+			expect: []Command{
+				{Name: "kind", Args: []string{"bug"}},
+			},
+		},
+		{
+			caseName: "Skip commands in unterminated HTML comments",
+			body:     "/retest\n<!--\n/assign",
+			expect: []Command{
+				{Name: "retest"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, ParseCommands(tc.body))
+		})
+	}
+}
+
+func TestCommandString(t *testing.T) {
+	cases := []struct {
+		caseName string
+		command  Command
+		expect   string
+	}{
+		{
+			caseName: "Command without arguments",
+			command:  Command{Name: "retest"},
+			expect:   "/retest",
+		},
+		{
+			caseName: "Command with arguments",
+			command:  Command{Name: "area", Args: []string{"core", "runtime"}},
+			expect:   "/area core runtime",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.command.String())
+		})
+	}
+}
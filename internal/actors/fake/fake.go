@@ -0,0 +1,650 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides an in-memory GitHub repository implementing the
+// services of actors.Client, so that the actors can be tested without the network.
+// Every call is recorded and changes the state of the repository as GitHub would.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+// BotLogin is the default login of the user creating the comments through the fake.
+const BotLogin = actors.DefaultBotLogin
+
+// Call is a recorded call of the GitHub API.
+type Call struct {
+	// Method is the service and the method called, such as "Issues.AddAssignees".
+	Method string
+
+	// Args are the arguments of the call following the owner and the repository.
+	Args []any
+}
+
+// GitHub is an in-memory GitHub repository, the owner and the name of the
+// repository passed to the services are recorded but not checked. Removals
+// replace the slices of the objects, so the slices returned earlier are unchanged.
+type GitHub struct {
+	// Login is the login of the user behind the token, such as the owner of a personal
+	// access token, the comments are created by it. BotLogin is used by default.
+	Login string
+
+	// Issues are the issues and pull requests of the repository by number.
+	Issues map[int]*github.Issue
+
+	// PullRequests are the pull requests of the repository by number,
+	// they are expected to have an issue of the same number as on GitHub.
+	PullRequests map[int]*github.PullRequest
+
+	// Comments are the comments of the issues and pull requests by number, oldest first.
+	Comments map[int][]*github.IssueComment
+
+	// Timelines are the timeline events of the issues by number.
+	Timelines map[int][]*github.Timeline
+
+	// Labels are the labels of the repository.
+	Labels []*github.Label
+
+	// Collaborators are the role names of the repository collaborators by login,
+	// such as "write", users that are not listed are not collaborators.
+	Collaborators map[string]string
+
+	// CheckRuns are the check runs of the commits by ref.
+	CheckRuns map[string][]*github.CheckRun
+
+	// Files are the files changed by the pull requests by number.
+	Files map[int][]*github.CommitFile
+
+	// Contents are the contents of the files of the default branch by path.
+	Contents map[string][]byte
+
+	// SearchResults are the issues returned by the issue search by query.
+	SearchResults map[string][]*github.Issue
+
+	// Statuses are the statuses published on the commits by ref.
+	Statuses map[string][]*github.RepoStatus
+
+	// Reactions are the reactions to the comments by comment ID.
+	Reactions map[int64][]string
+
+	// Reviews are the reviews submitted on the pull requests by number.
+	Reviews map[int][]*github.PullRequestReviewRequest
+
+	// Errors makes the calls of the methods fail, keyed by method such as "Issues.Get".
+	Errors map[string]error
+
+	// Calls are the calls of the GitHub API in the order they were made.
+	Calls []Call
+
+	lastID int64
+}
+
+// New returns an empty repository.
+func New() *GitHub {
+	return &GitHub{
+		Login:         BotLogin,
+		Issues:        make(map[int]*github.Issue),
+		PullRequests:  make(map[int]*github.PullRequest),
+		Comments:      make(map[int][]*github.IssueComment),
+		Timelines:     make(map[int][]*github.Timeline),
+		Collaborators: make(map[string]string),
+		CheckRuns:     make(map[string][]*github.CheckRun),
+		Files:         make(map[int][]*github.CommitFile),
+		Contents:      make(map[string][]byte),
+		SearchResults: make(map[string][]*github.Issue),
+		Statuses:      make(map[string][]*github.RepoStatus),
+		Reactions:     make(map[int64][]string),
+		Reviews:       make(map[int][]*github.PullRequestReviewRequest),
+		Errors:        make(map[string]error),
+	}
+}
+
+// Client returns the client calling the repository as the user with the Login.
+func (g *GitHub) Client() *actors.Client {
+	client := &actors.Client{
+		Issues:       &issues{g},
+		Reactions:    &reactions{g},
+		Checks:       &checks{g},
+		Actions:      &actionsService{g},
+		PullRequests: &pullRequests{g},
+		Repositories: &repositories{g},
+		Search:       &search{g},
+		Git:          &git{g},
+		Users:        &users{g},
+	}
+
+	return client.WithBotLogin(g.Login)
+}
+
+// Called returns the calls of the method, such as "Issues.AddAssignees".
+func (g *GitHub) Called(method string) []Call {
+	var calls []Call
+	for _, call := range g.Calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// LabelNames returns the sorted names of the labels of the issue.
+func (g *GitHub) LabelNames(number int) []string {
+	var names []string
+	if issue, ok := g.Issues[number]; ok {
+		for _, label := range issue.Labels {
+			names = append(names, label.GetName())
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// AssigneeLogins returns the logins of the assignees of the issue.
+func (g *GitHub) AssigneeLogins(number int) []string {
+	var logins []string
+	if issue, ok := g.Issues[number]; ok {
+		for _, assignee := range issue.Assignees {
+			logins = append(logins, assignee.GetLogin())
+		}
+	}
+
+	return logins
+}
+
+// CommentBodies returns the bodies of the comments of the issue.
+func (g *GitHub) CommentBodies(number int) []string {
+	var bodies []string
+	for _, comment := range g.Comments[number] {
+		bodies = append(bodies, comment.GetBody())
+	}
+
+	return bodies
+}
+
+// call records the call and returns the error configured for the method.
+func (g *GitHub) call(method string, args ...any) error {
+	g.Calls = append(g.Calls, Call{Method: method, Args: args})
+
+	return g.Errors[method]
+}
+
+// user returns the user behind the token.
+func (g *GitHub) user() *github.User {
+	userType := "User"
+	if strings.HasSuffix(g.Login, "[bot]") {
+		userType = "Bot"
+	}
+
+	return &github.User{Login: github.Ptr(g.Login), Type: github.Ptr(userType)}
+}
+
+func (g *GitHub) nextID() int64 {
+	g.lastID++
+
+	return g.lastID
+}
+
+func ok() *github.Response {
+	return &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}
+}
+
+func notFound(format string, args ...any) (*github.Response, error) {
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+	return resp, &github.ErrorResponse{Response: resp.Response, Message: fmt.Sprintf(format, args...)}
+}
+
+type issues struct{ *GitHub }
+
+func (s *issues) Get(_ context.Context, _, _ string, number int) (*github.Issue, *github.Response, error) {
+	if err := s.call("Issues.Get", number); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+
+	return issue, ok(), nil
+}
+
+func (s *issues) Edit(_ context.Context, _, _ string, number int, request *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	if err := s.call("Issues.Edit", number, request); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+	if request.State != nil {
+		issue.State = request.State
+	}
+	if request.StateReason != nil {
+		issue.StateReason = request.StateReason
+	}
+
+	return issue, ok(), nil
+}
+
+func (s *issues) ListByRepo(_ context.Context, _, _ string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	if err := s.call("Issues.ListByRepo", opts); err != nil {
+		return nil, nil, err
+	}
+
+	var ret []*github.Issue
+	for _, number := range slices.Sorted(maps.Keys(s.Issues)) {
+		issue := s.Issues[number]
+		if opts != nil && len(opts.State) != 0 && opts.State != "all" && opts.State != issue.GetState() {
+			continue
+		}
+		if opts != nil && len(opts.Assignee) != 0 && !assignedTo(issue, opts.Assignee) {
+			continue
+		}
+		ret = append(ret, issue)
+	}
+
+	return ret, ok(), nil
+}
+
+func (s *issues) ListIssueTimeline(_ context.Context, _, _ string, number int, _ *github.ListOptions) ([]*github.Timeline, *github.Response, error) {
+	if err := s.call("Issues.ListIssueTimeline", number); err != nil {
+		return nil, nil, err
+	}
+
+	return s.Timelines[number], ok(), nil
+}
+
+func (s *issues) CreateComment(_ context.Context, _, _ string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	if err := s.call("Issues.CreateComment", number, comment.GetBody()); err != nil {
+		return nil, nil, err
+	}
+	created := &github.IssueComment{
+		ID:   github.Ptr(s.nextID()),
+		Body: github.Ptr(comment.GetBody()),
+		User: s.user(),
+	}
+	s.Comments[number] = append(s.Comments[number], created)
+
+	return created, ok(), nil
+}
+
+func (s *issues) EditComment(_ context.Context, _, _ string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	if err := s.call("Issues.EditComment", commentID, comment.GetBody()); err != nil {
+		return nil, nil, err
+	}
+	for _, comments := range s.Comments {
+		for _, existing := range comments {
+			if existing.GetID() == commentID {
+				existing.Body = github.Ptr(comment.GetBody())
+				return existing, ok(), nil
+			}
+		}
+	}
+	resp, err := notFound("comment %d not found", commentID)
+
+	return nil, resp, err
+}
+
+func (s *issues) ListComments(_ context.Context, _, _ string, number int, _ *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	if err := s.call("Issues.ListComments", number); err != nil {
+		return nil, nil, err
+	}
+
+	return s.Comments[number], ok(), nil
+}
+
+func (s *issues) ListLabels(_ context.Context, _, _ string, _ *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	if err := s.call("Issues.ListLabels"); err != nil {
+		return nil, nil, err
+	}
+
+	return s.Labels, ok(), nil
+}
+
+func (s *issues) ListLabelsByIssue(_ context.Context, _, _ string, number int, _ *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	if err := s.call("Issues.ListLabelsByIssue", number); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+
+	return issue.Labels, ok(), nil
+}
+
+func (s *issues) AddLabelsToIssue(_ context.Context, _, _ string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	if err := s.call("Issues.AddLabelsToIssue", number, labels); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+	for _, label := range labels {
+		if !hasLabel(issue, label) {
+			issue.Labels = append(issue.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+	}
+
+	return issue.Labels, ok(), nil
+}
+
+func (s *issues) RemoveLabelForIssue(_ context.Context, _, _ string, number int, label string) (*github.Response, error) {
+	if err := s.call("Issues.RemoveLabelForIssue", number, label); err != nil {
+		return nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found || !hasLabel(issue, label) {
+		return notFound("label '%s' not found on issue #%d", label, number)
+	}
+	issue.Labels = slices.DeleteFunc(slices.Clone(issue.Labels), func(l *github.Label) bool {
+		return l.GetName() == label
+	})
+
+	return ok(), nil
+}
+
+func (s *issues) IsAssignee(_ context.Context, _, _, user string) (bool, *github.Response, error) {
+	if err := s.call("Issues.IsAssignee", user); err != nil {
+		return false, nil, err
+	}
+	_, found := s.Collaborators[user]
+
+	return found, ok(), nil
+}
+
+func (s *issues) AddAssignees(_ context.Context, _, _ string, number int, assignees []string) (*github.Issue, *github.Response, error) {
+	if err := s.call("Issues.AddAssignees", number, assignees); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+	for _, login := range assignees {
+		if !assignedTo(issue, login) {
+			issue.Assignees = append(issue.Assignees, &github.User{Login: github.Ptr(login)})
+		}
+	}
+
+	return issue, ok(), nil
+}
+
+func (s *issues) RemoveAssignees(_ context.Context, _, _ string, number int, assignees []string) (*github.Issue, *github.Response, error) {
+	if err := s.call("Issues.RemoveAssignees", number, assignees); err != nil {
+		return nil, nil, err
+	}
+	issue, found := s.Issues[number]
+	if !found {
+		resp, err := notFound("issue #%d not found", number)
+		return nil, resp, err
+	}
+	issue.Assignees = slices.DeleteFunc(slices.Clone(issue.Assignees), func(user *github.User) bool {
+		return slices.ContainsFunc(assignees, func(login string) bool {
+			return strings.EqualFold(login, user.GetLogin())
+		})
+	})
+
+	return issue, ok(), nil
+}
+
+type reactions struct{ *GitHub }
+
+func (s *reactions) CreateIssueCommentReaction(_ context.Context, _, _ string, id int64, content string) (*github.Reaction, *github.Response, error) {
+	if err := s.call("Reactions.CreateIssueCommentReaction", id, content); err != nil {
+		return nil, nil, err
+	}
+	s.Reactions[id] = append(s.Reactions[id], content)
+
+	return &github.Reaction{Content: github.Ptr(content)}, ok(), nil
+}
+
+type checks struct{ *GitHub }
+
+func (s *checks) ListCheckRunsForRef(_ context.Context, _, _, ref string, _ *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	if err := s.call("Checks.ListCheckRunsForRef", ref); err != nil {
+		return nil, nil, err
+	}
+	runs := s.CheckRuns[ref]
+
+	return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}, ok(), nil
+}
+
+type actionsService struct{ *GitHub }
+
+func (s *actionsService) RerunJobByID(_ context.Context, _, _ string, jobID int64) (*github.Response, error) {
+	if err := s.call("Actions.RerunJobByID", jobID); err != nil {
+		return nil, err
+	}
+
+	return ok(), nil
+}
+
+type pullRequests struct{ *GitHub }
+
+func (s *pullRequests) Get(_ context.Context, _, _ string, number int) (*github.PullRequest, *github.Response, error) {
+	if err := s.call("PullRequests.Get", number); err != nil {
+		return nil, nil, err
+	}
+	pr, found := s.PullRequests[number]
+	if !found {
+		resp, err := notFound("pull request #%d not found", number)
+		return nil, resp, err
+	}
+
+	return pr, ok(), nil
+}
+
+func (s *pullRequests) ListFiles(_ context.Context, _, _ string, number int, _ *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	if err := s.call("PullRequests.ListFiles", number); err != nil {
+		return nil, nil, err
+	}
+
+	return s.Files[number], ok(), nil
+}
+
+func (s *pullRequests) RequestReviewers(_ context.Context, _, _ string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error) {
+	if err := s.call("PullRequests.RequestReviewers", number, reviewers); err != nil {
+		return nil, nil, err
+	}
+	pr, found := s.PullRequests[number]
+	if !found {
+		resp, err := notFound("pull request #%d not found", number)
+		return nil, resp, err
+	}
+	for _, login := range reviewers.Reviewers {
+		pr.RequestedReviewers = append(pr.RequestedReviewers, &github.User{Login: github.Ptr(login)})
+	}
+	for _, slug := range reviewers.TeamReviewers {
+		pr.RequestedTeams = append(pr.RequestedTeams, &github.Team{Slug: github.Ptr(slug)})
+	}
+
+	return pr, ok(), nil
+}
+
+func (s *pullRequests) RemoveReviewers(_ context.Context, _, _ string, number int, reviewers github.ReviewersRequest) (*github.Response, error) {
+	if err := s.call("PullRequests.RemoveReviewers", number, reviewers); err != nil {
+		return nil, err
+	}
+	pr, found := s.PullRequests[number]
+	if !found {
+		return notFound("pull request #%d not found", number)
+	}
+	pr.RequestedReviewers = slices.DeleteFunc(slices.Clone(pr.RequestedReviewers), func(user *github.User) bool {
+		return slices.Contains(reviewers.Reviewers, user.GetLogin())
+	})
+	pr.RequestedTeams = slices.DeleteFunc(slices.Clone(pr.RequestedTeams), func(team *github.Team) bool {
+		return slices.Contains(reviewers.TeamReviewers, team.GetSlug())
+	})
+
+	return ok(), nil
+}
+
+func (s *pullRequests) CreateReview(_ context.Context, _, _ string, number int, review *github.PullRequestReviewRequest) (*github.PullRequestReview, *github.Response, error) {
+	if err := s.call("PullRequests.CreateReview", number, review); err != nil {
+		return nil, nil, err
+	}
+	s.Reviews[number] = append(s.Reviews[number], review)
+
+	return &github.PullRequestReview{ID: github.Ptr(s.nextID()), State: review.Event}, ok(), nil
+}
+
+type repositories struct{ *GitHub }
+
+func (s *repositories) GetContents(_ context.Context, _, _, path string, _ *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	if err := s.call("Repositories.GetContents", path); err != nil {
+		return nil, nil, nil, err
+	}
+	content, found := s.Contents[path]
+	if !found {
+		resp, err := notFound("file '%s' not found", path)
+		return nil, nil, resp, err
+	}
+
+	return &github.RepositoryContent{
+		Type:    github.Ptr("file"),
+		Path:    github.Ptr(path),
+		Content: github.Ptr(string(content)),
+	}, nil, ok(), nil
+}
+
+func (s *repositories) CreateStatus(_ context.Context, _, _, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	if err := s.call("Repositories.CreateStatus", ref, status); err != nil {
+		return nil, nil, err
+	}
+	s.Statuses[ref] = append(s.Statuses[ref], status)
+
+	return status, ok(), nil
+}
+
+func (s *repositories) IsCollaborator(_ context.Context, _, _, user string) (bool, *github.Response, error) {
+	if err := s.call("Repositories.IsCollaborator", user); err != nil {
+		return false, nil, err
+	}
+	_, found := s.Collaborators[user]
+
+	return found, ok(), nil
+}
+
+func (s *repositories) GetPermissionLevel(_ context.Context, _, _, user string) (*github.RepositoryPermissionLevel, *github.Response, error) {
+	if err := s.call("Repositories.GetPermissionLevel", user); err != nil {
+		return nil, nil, err
+	}
+	role, found := s.Collaborators[user]
+	if !found {
+		resp, err := notFound("user '%s' is not a collaborator", user)
+		return nil, resp, err
+	}
+
+	return &github.RepositoryPermissionLevel{
+		Permission: github.Ptr(permissionOf(role)),
+		RoleName:   github.Ptr(role),
+		User:       &github.User{Login: github.Ptr(user)},
+	}, ok(), nil
+}
+
+type search struct{ *GitHub }
+
+func (s *search) Issues(_ context.Context, query string, _ *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	if err := s.call("Search.Issues", query); err != nil {
+		return nil, nil, err
+	}
+	issues := s.SearchResults[query]
+
+	return &github.IssuesSearchResult{Total: github.Ptr(len(issues)), Issues: issues}, ok(), nil
+}
+
+type git struct{ *GitHub }
+
+func (s *git) GetTree(_ context.Context, _, _, sha string, _ bool) (*github.Tree, *github.Response, error) {
+	if err := s.call("Git.GetTree", sha); err != nil {
+		return nil, nil, err
+	}
+
+	tree := &github.Tree{SHA: github.Ptr(sha), Truncated: github.Ptr(false)}
+	for _, path := range slices.Sorted(maps.Keys(s.Contents)) {
+		tree.Entries = append(tree.Entries, &github.TreeEntry{Path: github.Ptr(path), Type: github.Ptr("blob")})
+	}
+
+	return tree, ok(), nil
+}
+
+func assignedTo(issue *github.Issue, login string) bool {
+	if login == "*" {
+		return len(issue.Assignees) != 0
+	}
+
+	return slices.ContainsFunc(issue.Assignees, func(user *github.User) bool {
+		return strings.EqualFold(user.GetLogin(), login)
+	})
+}
+
+func hasLabel(issue *github.Issue, name string) bool {
+	return slices.ContainsFunc(issue.Labels, func(label *github.Label) bool {
+		return label.GetName() == name
+	})
+}
+
+// permissionOf returns the legacy permission reported along with the role,
+// GitHub reports the triage and maintain roles as the read and write permissions.
+func permissionOf(role string) string {
+	switch role {
+	case "triage":
+		return "read"
+	case "maintain":
+		return "write"
+	default:
+		return role
+	}
+}
+
+type users struct{ *GitHub }
+
+// Get returns the user of the login, or the user behind the token for an empty login.
+// As on GitHub, the bots of the GitHub Apps, such as the one of the GITHUB_TOKEN, cannot read themselves.
+func (s *users) Get(_ context.Context, login string) (*github.User, *github.Response, error) {
+	if err := s.call("Users.Get", login); err != nil {
+		return nil, nil, err
+	}
+	if len(login) != 0 {
+		return &github.User{Login: github.Ptr(login), Type: github.Ptr("User")}, ok(), nil
+	}
+
+	user := s.user()
+	if user.GetType() == "Bot" {
+		resp := &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}
+		return nil, resp, &github.ErrorResponse{Response: resp.Response, Message: "Resource not accessible by integration"}
+	}
+
+	return user, ok(), nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// apiPrefix is the path prefix of the REST API of GitHub Enterprise,
+// which go-github uses with the server URL passed to WithEnterpriseURLs.
+const apiPrefix = "/api/v3"
+
+// NewServer serves the repository over the GitHub REST API, so that the go-github client
+// configured with github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL) sees
+// the same state as the Client of the repository. The caller closes the server.
+func (g *GitHub) NewServer() *httptest.Server {
+	var (
+		ctx    = context.Background()
+		client = g.Client()
+		mux    = http.NewServeMux()
+	)
+	handle := func(pattern string, handler func(r *http.Request) (any, *github.Response, error)) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+apiPrefix+path, func(w http.ResponseWriter, r *http.Request) {
+			v, resp, err := handler(r)
+			reply(w, v, resp, err)
+		})
+	}
+
+	// issues
+	handle("GET /repos/{owner}/{repo}/issues", func(r *http.Request) (any, *github.Response, error) {
+		query := r.URL.Query()
+		return client.Issues.ListByRepo(ctx, r.PathValue("owner"), r.PathValue("repo"), &github.IssueListByRepoOptions{
+			State:    query.Get("state"),
+			Assignee: query.Get("assignee"),
+		})
+	})
+	handle("GET /repos/{owner}/{repo}/issues/{number}", func(r *http.Request) (any, *github.Response, error) {
+		return client.Issues.Get(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r))
+	})
+	handle("PATCH /repos/{owner}/{repo}/issues/{number}", func(r *http.Request) (any, *github.Response, error) {
+		var request github.IssueRequest
+		if err := decode(r, &request); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.Edit(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), &request)
+	})
+	handle("GET /repos/{owner}/{repo}/issues/{number}/timeline", func(r *http.Request) (any, *github.Response, error) {
+		return client.Issues.ListIssueTimeline(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), nil)
+	})
+
+	// comments
+	handle("GET /repos/{owner}/{repo}/issues/{number}/comments", func(r *http.Request) (any, *github.Response, error) {
+		return client.Issues.ListComments(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), nil)
+	})
+	handle("POST /repos/{owner}/{repo}/issues/{number}/comments", func(r *http.Request) (any, *github.Response, error) {
+		var comment github.IssueComment
+		if err := decode(r, &comment); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.CreateComment(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), &comment)
+	})
+	handle("PATCH /repos/{owner}/{repo}/issues/comments/{id}", func(r *http.Request) (any, *github.Response, error) {
+		var comment github.IssueComment
+		if err := decode(r, &comment); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.EditComment(ctx, r.PathValue("owner"), r.PathValue("repo"), id(r), &comment)
+	})
+	handle("POST /repos/{owner}/{repo}/issues/comments/{id}/reactions", func(r *http.Request) (any, *github.Response, error) {
+		var reaction github.Reaction
+		if err := decode(r, &reaction); err != nil {
+			return nil, nil, err
+		}
+		return client.Reactions.CreateIssueCommentReaction(ctx, r.PathValue("owner"), r.PathValue("repo"), id(r), reaction.GetContent())
+	})
+
+	// labels
+	handle("GET /repos/{owner}/{repo}/labels", func(r *http.Request) (any, *github.Response, error) {
+		return client.Issues.ListLabels(ctx, r.PathValue("owner"), r.PathValue("repo"), nil)
+	})
+	handle("GET /repos/{owner}/{repo}/issues/{number}/labels", func(r *http.Request) (any, *github.Response, error) {
+		return client.Issues.ListLabelsByIssue(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), nil)
+	})
+	handle("POST /repos/{owner}/{repo}/issues/{number}/labels", func(r *http.Request) (any, *github.Response, error) {
+		var labels []string
+		if err := decode(r, &labels); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.AddLabelsToIssue(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), labels)
+	})
+	handle("DELETE /repos/{owner}/{repo}/issues/{number}/labels/{label...}", func(r *http.Request) (any, *github.Response, error) {
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), r.PathValue("label"))
+		return nil, resp, err
+	})
+
+	// assignees
+	handle("GET /repos/{owner}/{repo}/assignees/{user}", func(r *http.Request) (any, *github.Response, error) {
+		assignable, resp, err := client.Issues.IsAssignee(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("user"))
+		return nil, boolResponse(assignable, resp), err
+	})
+	handle("POST /repos/{owner}/{repo}/issues/{number}/assignees", func(r *http.Request) (any, *github.Response, error) {
+		var request struct {
+			Assignees []string `json:"assignees"`
+		}
+		if err := decode(r, &request); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.AddAssignees(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), request.Assignees)
+	})
+	handle("DELETE /repos/{owner}/{repo}/issues/{number}/assignees", func(r *http.Request) (any, *github.Response, error) {
+		var request struct {
+			Assignees []string `json:"assignees"`
+		}
+		if err := decode(r, &request); err != nil {
+			return nil, nil, err
+		}
+		return client.Issues.RemoveAssignees(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), request.Assignees)
+	})
+
+	// checks and actions
+	handle("GET /repos/{owner}/{repo}/commits/{ref}/check-runs", func(r *http.Request) (any, *github.Response, error) {
+		return client.Checks.ListCheckRunsForRef(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("ref"), nil)
+	})
+	handle("POST /repos/{owner}/{repo}/actions/jobs/{id}/rerun", func(r *http.Request) (any, *github.Response, error) {
+		resp, err := client.Actions.RerunJobByID(ctx, r.PathValue("owner"), r.PathValue("repo"), id(r))
+		return nil, resp, err
+	})
+
+	// pull requests
+	handle("GET /repos/{owner}/{repo}/pulls/{number}", func(r *http.Request) (any, *github.Response, error) {
+		return client.PullRequests.Get(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r))
+	})
+	handle("GET /repos/{owner}/{repo}/pulls/{number}/files", func(r *http.Request) (any, *github.Response, error) {
+		return client.PullRequests.ListFiles(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), nil)
+	})
+	handle("POST /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", func(r *http.Request) (any, *github.Response, error) {
+		var reviewers github.ReviewersRequest
+		if err := decode(r, &reviewers); err != nil {
+			return nil, nil, err
+		}
+		return client.PullRequests.RequestReviewers(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), reviewers)
+	})
+	handle("DELETE /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", func(r *http.Request) (any, *github.Response, error) {
+		var reviewers github.ReviewersRequest
+		if err := decode(r, &reviewers); err != nil {
+			return nil, nil, err
+		}
+		resp, err := client.PullRequests.RemoveReviewers(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), reviewers)
+		return nil, resp, err
+	})
+	handle("POST /repos/{owner}/{repo}/pulls/{number}/reviews", func(r *http.Request) (any, *github.Response, error) {
+		var review github.PullRequestReviewRequest
+		if err := decode(r, &review); err != nil {
+			return nil, nil, err
+		}
+		return client.PullRequests.CreateReview(ctx, r.PathValue("owner"), r.PathValue("repo"), number(r), &review)
+	})
+
+	// repositories
+	handle("GET /repos/{owner}/{repo}/contents/{path...}", func(r *http.Request) (any, *github.Response, error) {
+		file, _, resp, err := client.Repositories.GetContents(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("path"), nil)
+		return file, resp, err
+	})
+	handle("POST /repos/{owner}/{repo}/statuses/{ref}", func(r *http.Request) (any, *github.Response, error) {
+		var status github.RepoStatus
+		if err := decode(r, &status); err != nil {
+			return nil, nil, err
+		}
+		return client.Repositories.CreateStatus(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("ref"), &status)
+	})
+	handle("GET /repos/{owner}/{repo}/collaborators/{user}", func(r *http.Request) (any, *github.Response, error) {
+		isCollaborator, resp, err := client.Repositories.IsCollaborator(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("user"))
+		return nil, boolResponse(isCollaborator, resp), err
+	})
+	handle("GET /repos/{owner}/{repo}/collaborators/{user}/permission", func(r *http.Request) (any, *github.Response, error) {
+		return client.Repositories.GetPermissionLevel(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("user"))
+	})
+	handle("GET /repos/{owner}/{repo}/git/trees/{sha}", func(r *http.Request) (any, *github.Response, error) {
+		return client.Git.GetTree(ctx, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("sha"), r.URL.Query().Has("recursive"))
+	})
+
+	// users
+	handle("GET /user", func(_ *http.Request) (any, *github.Response, error) {
+		return client.Users.Get(ctx, "")
+	})
+
+	// search
+	handle("GET /search/issues", func(r *http.Request) (any, *github.Response, error) {
+		return client.Search.Issues(ctx, r.URL.Query().Get("q"), nil)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// reply writes the result of a service call as GitHub would.
+func reply(w http.ResponseWriter, v any, resp *github.Response, err error) {
+	status := http.StatusOK
+	if resp != nil && resp.Response != nil {
+		status = resp.StatusCode
+	}
+
+	var errResp *github.ErrorResponse
+	switch {
+	case errors.As(err, &errResp):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": errResp.Message})
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case v == nil:
+		if status == http.StatusOK {
+			status = http.StatusNoContent
+		}
+		w.WriteHeader(status)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+// boolResponse turns the result of the GitHub APIs answering with a status code,
+// such as checking a collaborator, into that status code.
+func boolResponse(ok bool, resp *github.Response) *github.Response {
+	status := http.StatusNoContent
+	if !ok {
+		status = http.StatusNotFound
+	}
+	if resp == nil {
+		return nil
+	}
+
+	return &github.Response{Response: &http.Response{StatusCode: status}}
+}
+
+func decode(r *http.Request, v any) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func number(r *http.Request) int {
+	n, _ := strconv.Atoi(r.PathValue("number"))
+
+	return n
+}
+
+func id(r *http.Request) int64 {
+	n, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	return n
+}
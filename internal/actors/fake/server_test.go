@@ -0,0 +1,68 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+func TestServer(t *testing.T) {
+	gh := New()
+	gh.Collaborators["alice"] = "write"
+	gh.Contents["OWNERS"] = []byte("approvers: [alice]")
+	gh.Issues[1] = &github.Issue{
+		Number: github.Ptr(1),
+		State:  github.Ptr("open"),
+		Labels: []*github.Label{{Name: github.Ptr("area/core")}, {Name: github.Ptr("help wanted")}},
+	}
+	server := gh.NewServer()
+	defer server.Close()
+
+	ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+	assert.NoError(t, err)
+	client := actors.NewClient(ghClient)
+
+	// label names are escaped in the path
+	assert.NoError(t, actors.RemoveLabelToIssue(client, "owner/repo", 1, "area/core"))
+	assert.NoError(t, actors.RemoveLabelToIssue(client, "owner/repo", 1, "help wanted"))
+	assert.Empty(t, gh.LabelNames(1))
+
+	assert.NoError(t, actors.AddComment(client, "hello", "owner/repo", 1))
+	assert.Equal(t, []string{"hello"}, gh.CommentBodies(1))
+	assert.NoError(t, actors.AddReaction(client, actors.CommendReaction, "owner/repo", gh.Comments[1][0].GetID()))
+	assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[gh.Comments[1][0].GetID()])
+
+	assignable, _, err := client.Issues.IsAssignee(t.Context(), "owner", "repo", "alice")
+	assert.NoError(t, err)
+	assert.True(t, assignable)
+	assignable, _, err = client.Issues.IsAssignee(t.Context(), "owner", "repo", "bob")
+	assert.NoError(t, err)
+	assert.False(t, assignable)
+
+	content, err := actors.GetFileContent(client, "owner/repo", "OWNERS")
+	assert.NoError(t, err)
+	assert.Equal(t, "approvers: [alice]", string(content))
+	content, err = actors.GetFileContent(client, "owner/repo", "OWNERS_ALIASES")
+	assert.NoError(t, err)
+	assert.Nil(t, content)
+
+	_, _, err = client.Issues.Get(t.Context(), "owner", "repo", 2)
+	assert.Error(t, err)
+}
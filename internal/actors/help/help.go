@@ -0,0 +1,182 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package help
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/permission"
+)
+
+const (
+	helpActorName = "HelpActor"
+
+	helpCommand = "help"
+)
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+	config   *config.Config
+
+	// permissions checks the role of the commenter for every command.
+	permissions *permission.Checker
+
+	// help are the commands of the enabled actors handling comments.
+	help []actors.CommandHelp
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
+}
+
+// NewHelpActor returns the actor replying to '/help' with the given commands,
+// those of the actors handling comments that are enabled in the repository.
+func NewHelpActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options, help []actors.CommandHelp) actors.Actor {
+	return &actor{
+		ghClient:    ghClient,
+		logger:      logger,
+		config:      opts.Config,
+		permissions: opts.Permissions,
+		help:        help,
+	}
+}
+
+func (a *actor) Handler() error {
+	var (
+		issue  = a.event.GetIssue()
+		repo   = a.event.GetRepo()
+		login  = a.event.GetComment().GetUser().GetLogin()
+		author = issue.GetUser().GetLogin()
+	)
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	var available, restricted []string
+	for _, command := range append(a.help, a.Help()...) {
+		if issue.IsPullRequest() && !command.PullRequests || !issue.IsPullRequest() && !command.Issues {
+			continue
+		}
+
+		role := command.Role
+		if len(role) == 0 {
+			role = a.config.CommandRole(command.Name)
+		}
+		allowed := a.config.AuthorCommand(command.Name) && strings.EqualFold(login, author)
+		if !allowed {
+			has, err := a.permissions.HasRole(login, role)
+			if err != nil {
+				return err
+			}
+			allowed = has
+		}
+
+		if allowed {
+			available = append(available, fmt.Sprintf("| %s | %s | %s |", code(command.Syntax), escape(command.Description), roleOf(role)))
+		} else {
+			restricted = append(restricted, fmt.Sprintf("%s (requires the `%s` role)", code(command.Syntax), role))
+		}
+	}
+
+	return actors.AddComment(a.ghClient, message(login, kindOf(issue), available, restricted), repo.GetFullName(), issue.GetNumber())
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	commentEvent, ok := event.Event.(github.IssueCommentEvent)
+	if !ok {
+		a.logger.Error("cannot extract event to github.IssueCommentEvent, please check event type")
+		return false
+	}
+
+	if commentEvent.Issue == nil || len(commentEvent.Comment.GetBody()) == 0 {
+		return false
+	}
+
+	commands := actors.ParseCommands(commentEvent.Comment.GetBody(), helpCommand)
+	if commands == nil {
+		return false
+	}
+	a.event = commentEvent
+	a.commands = commands
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return helpActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         helpCommand,
+			Syntax:       "/help",
+			Description:  "Lists the commands you can use here.",
+			Issues:       true,
+			PullRequests: true,
+		},
+	}
+}
+
+// message returns the reply listing the available commands in a Markdown table,
+// followed by the commands requiring a role the commenter does not have.
+func message(login, kind string, available, restricted []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s the following commands can be used on this %s:\n\n", login, kind)
+	b.WriteString("| Command | Description | Required role |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, row := range available {
+		b.WriteString(row + "\n")
+	}
+	if len(restricted) != 0 {
+		fmt.Fprintf(&b, "\nThe other commands require a role you do not have: %s.", strings.Join(restricted, ", "))
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func roleOf(role permission.Role) string {
+	if role == permission.RoleNone {
+		return "anyone"
+	}
+
+	return fmt.Sprintf("`%s`", role)
+}
+
+// code formats the syntax of a command as code in a table cell.
+func code(syntax string) string {
+	return "`" + escape(syntax) + "`"
+}
+
+// escape prevents pipes from ending the table cells, GitHub unescapes them in code spans too.
+func escape(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+func kindOf(issue *github.Issue) string {
+	if issue.IsPullRequest() {
+		return "pull request"
+	}
+
+	return "issue"
+}
@@ -0,0 +1,166 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package help
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/permission"
+)
+
+func TestHelpCapture(t *testing.T) {
+	cases := []struct {
+		caseName string
+		event    any
+		expect   bool
+	}{
+		{
+			caseName: "Capture help command on issue",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{Body: github.Ptr("/help")},
+				Issue:   &github.Issue{Number: github.Ptr(1)},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Capture help command on pull request",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{Body: github.Ptr("How do I retest?\n/help")},
+				Issue:   &github.Issue{Number: github.Ptr(2), PullRequestLinks: &github.PullRequestLinks{}},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Do not capture quoted help command",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{Body: github.Ptr("> /help")},
+				Issue:   &github.Issue{Number: github.Ptr(1)},
+			},
+			expect: false,
+		},
+		{
+			caseName: "Do not capture other events",
+			event:    github.IssuesEvent{},
+			expect:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			helpActor := &actor{logger: noopLogger()}
+			assert.Equal(t, tc.expect, helpActor.Capture(actors.GenericEvent{Event: tc.event}))
+		})
+	}
+}
+
+func TestHelpHandler(t *testing.T) {
+	commands := []actors.CommandHelp{
+		{Name: "assign", Syntax: "/assign", Description: "Assigns the issue to yourself.", Issues: true},
+		{Name: "assign", Syntax: "/assign @user...", Description: "Assigns the issue to other users.", Role: permission.RoleTriage, Issues: true},
+		{Name: "area", Syntax: "/area <area>...", Description: "Adds the `area/<area>` labels to the issue.", Issues: true},
+		{Name: "retest", Syntax: "/retest", Description: "Reruns the failed checks of the pull request.", PullRequests: true},
+		{Name: "lifecycle", Syntax: "/lifecycle <stale|rotten|frozen>", Description: "Marks the issue or the pull request.", Issues: true, PullRequests: true},
+		{Name: "close", Syntax: "/close", Description: "Closes the issue or the pull request.", Issues: true, PullRequests: true},
+	}
+	cases := []struct {
+		caseName    string
+		commenter   string
+		pullRequest bool
+		expect      string
+	}{
+		{
+			caseName:  "Reader on an issue",
+			commenter: "alice",
+			expect: "@alice the following commands can be used on this issue:\n\n" +
+				"| Command | Description | Required role |\n" +
+				"| --- | --- | --- |\n" +
+				"| `/assign` | Assigns the issue to yourself. | anyone |\n" +
+				"| `/help` | Lists the commands you can use here. | anyone |\n\n" +
+				"The other commands require a role you do not have: `/assign @user...` (requires the `triage` role), " +
+				"`/area <area>...` (requires the `triage` role), `/lifecycle <stale\\|rotten\\|frozen>` (requires the `triage` role), " +
+				"`/close` (requires the `triage` role).",
+		},
+		{
+			caseName:    "Author on a pull request",
+			commenter:   "carol",
+			pullRequest: true,
+			expect: "@carol the following commands can be used on this pull request:\n\n" +
+				"| Command | Description | Required role |\n" +
+				"| --- | --- | --- |\n" +
+				"| `/retest` | Reruns the failed checks of the pull request. | anyone |\n" +
+				"| `/close` | Closes the issue or the pull request. | `triage` |\n" +
+				"| `/help` | Lists the commands you can use here. | anyone |\n\n" +
+				"The other commands require a role you do not have: `/lifecycle <stale\\|rotten\\|frozen>` (requires the `triage` role).",
+		},
+		{
+			caseName:  "Triager on an issue",
+			commenter: "bob",
+			expect: "@bob the following commands can be used on this issue:\n\n" +
+				"| Command | Description | Required role |\n" +
+				"| --- | --- | --- |\n" +
+				"| `/assign` | Assigns the issue to yourself. | anyone |\n" +
+				"| `/assign @user...` | Assigns the issue to other users. | `triage` |\n" +
+				"| `/area <area>...` | Adds the `area/<area>` labels to the issue. | `triage` |\n" +
+				"| `/lifecycle <stale\\|rotten\\|frozen>` | Marks the issue or the pull request. | `triage` |\n" +
+				"| `/close` | Closes the issue or the pull request. | `triage` |\n" +
+				"| `/help` | Lists the commands you can use here. | anyone |",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Collaborators["alice"] = "read"
+			gh.Collaborators["bob"] = "triage"
+			gh.Collaborators["carol"] = "read"
+			gh.Issues[1] = &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), User: &github.User{Login: github.Ptr("carol")}}
+			if tc.pullRequest {
+				gh.Issues[1].PullRequestLinks = &github.PullRequestLinks{}
+			}
+
+			ghClient := gh.Client()
+			helpActor := NewHelpActor(ghClient, noopLogger(), &actors.Options{
+				Config:      config.Default(),
+				Permissions: permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+			}, commands)
+			captured := helpActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/help"), User: &github.User{Login: github.Ptr(tc.commenter)}},
+					Issue:   gh.Issues[1],
+					Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+
+			assert.NoError(t, helpActor.Handler())
+			assert.Equal(t, []string{tc.expect}, gh.CommentBodies(1))
+		})
+	}
+}
+
+func noopLogger() *slog.Logger {
+	return slog.NewWithConfig(func(l *slog.Logger) {
+		l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+	})
+}
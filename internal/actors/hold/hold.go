@@ -0,0 +1,229 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hold
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+const (
+	holdActorName = "HoldActor"
+
+	holdCommand   = "hold"
+	unholdCommand = "unhold"
+	cancelArg     = "cancel"
+
+	failureState = "failure"
+	successState = "success"
+)
+
+// statusActions are the 'pull_request' event actions that change the head commit
+// of the pull request, which needs the hold status to be published again.
+var statusActions = []string{"opened", "reopened", "synchronize"}
+
+// labelActions are the 'pull_request' event actions changing the labels, the hold status
+// follows the hold label when it is added or removed without the commands, such as in the UI.
+var labelActions = []string{"labeled", "unlabeled"}
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+	config   config.HoldConfig
+
+	// commentEvent is set when the actor is triggered by a '/[un]hold' command,
+	// pullRequestEvent when the head commit or the hold label of the pull request changes.
+	commentEvent     *github.IssueCommentEvent
+	pullRequestEvent *github.PullRequestEvent
+	commands         []actors.Command
+}
+
+func NewHoldActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient: ghClient,
+		logger:   logger,
+		config:   opts.Config.Actors.Hold,
+	}
+}
+
+func (a *actor) Handler() error {
+	if a.pullRequestEvent != nil {
+		return a.handlePullRequest()
+	}
+
+	var (
+		issue   = a.commentEvent.GetIssue()
+		repo    = a.commentEvent.GetRepo()
+		comment = a.commentEvent.GetComment()
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), issue.GetNumber())
+
+	pr, err := actors.GetPRFromIssue(a.ghClient, repo.GetFullName(), issue)
+	if err != nil {
+		return err
+	}
+
+	// the last command of the comment wins
+	hold := isHold(a.commands[len(a.commands)-1])
+	if hold {
+		err = actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label)
+	} else {
+		err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := a.publishStatus(repo.GetFullName(), pr.GetHead().GetSHA(), hold); err != nil {
+		return err
+	}
+	a.logger.Infof("pr #%d hold: %t", issue.GetNumber(), hold)
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return err
+	}
+	a.logger.Infof("add a reaction '%s' to comment %d of pr #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+
+	return nil
+}
+
+// handlePullRequest publishes the hold status of the labels on the head commit,
+// so that the hold survives new pushes and follows the hold label.
+func (a *actor) handlePullRequest() error {
+	var (
+		pr   = a.pullRequestEvent.GetPullRequest()
+		repo = a.pullRequestEvent.GetRepo()
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), pr.GetNumber())
+
+	hold := slices.ContainsFunc(pr.Labels, func(label *github.Label) bool {
+		return label.GetName() == a.config.Label
+	})
+
+	return a.publishStatus(repo.GetFullName(), pr.GetHead().GetSHA(), hold)
+}
+
+// publishStatus publishes a failing commit status on the commit when the pull request is on hold,
+// and a successful one otherwise so that the status can be required by the branch protection.
+func (a *actor) publishStatus(repoFullName, sha string, hold bool) error {
+	status := &github.RepoStatus{
+		State:       github.Ptr(successState),
+		Context:     github.Ptr(a.config.StatusContext),
+		Description: github.Ptr("The PR is not on hold"),
+	}
+	if hold {
+		status.State = github.Ptr(failureState)
+		status.Description = github.Ptr("The PR is on hold, comment /unhold to release it")
+	}
+
+	owner, repoName := actors.GetOwnerRepo(repoFullName)
+	if _, _, err := a.ghClient.Repositories.CreateStatus(a.ghClient.Context(), owner, repoName, sha, status); err != nil {
+		return err
+	}
+	a.logger.Infof("publish '%s' status '%s' on commit %s", a.config.StatusContext, status.GetState(), sha)
+
+	return nil
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	switch evt := event.Event.(type) {
+	case github.IssueCommentEvent:
+		if !evt.Issue.IsPullRequest() || len(evt.Comment.GetBody()) == 0 {
+			return false
+		}
+		if evt.Issue.GetClosedBy() != nil || !evt.Issue.GetClosedAt().IsZero() {
+			return false
+		}
+
+		commands := parseCommands(evt.Comment.GetBody())
+		if commands == nil {
+			return false
+		}
+		a.commentEvent = &evt
+		a.commands = commands
+
+		return true
+	case github.PullRequestEvent:
+		if evt.PullRequest == nil || evt.PullRequest.GetState() == "closed" {
+			return false
+		}
+		switch {
+		case slices.Contains(statusActions, evt.GetAction()):
+		case slices.Contains(labelActions, evt.GetAction()) && evt.GetLabel().GetName() == a.config.Label:
+		default:
+			return false
+		}
+		a.pullRequestEvent = &evt
+
+		return true
+	default:
+		a.logger.Error("cannot extract event to github.IssueCommentEvent or github.PullRequestEvent, please check event type")
+		return false
+	}
+}
+
+func (a *actor) Name() string {
+	return holdActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         holdCommand,
+			Syntax:       "/hold",
+			Description:  fmt.Sprintf("Prevents the pull request from being merged with the `%s` label.", a.config.Label),
+			PullRequests: true,
+		},
+		{
+			Name:         unholdCommand,
+			Syntax:       "/unhold",
+			Description:  "Allows the pull request to be merged again.",
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/hold', '/hold cancel' and '/unhold' commands of the comment body,
+// commands with other arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, holdCommand, unholdCommand) {
+		if len(command.Args) == 0 || (command.Name == holdCommand && isCancel(command)) {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+// isHold reports whether the command puts the pull request on hold.
+func isHold(command actors.Command) bool {
+	return command.Name == holdCommand && !isCancel(command)
+}
+
+func isCancel(command actors.Command) bool {
+	return len(command.Args) == 1 && strings.EqualFold(command.Args[0], cancelArg)
+}
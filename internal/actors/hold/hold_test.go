@@ -0,0 +1,315 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hold
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+func TestHoldCommentBodyMatch(t *testing.T) {
+	cases := []struct {
+		caseName   string
+		comment    string
+		expectHold []bool
+	}{
+		{
+			caseName:   "Match the hold instruction",
+			comment:    "/hold",
+			expectHold: []bool{true},
+		},
+		{
+			caseName:   "Match the hold cancel instruction",
+			comment:    "/hold cancel",
+			expectHold: []bool{false},
+		},
+		{
+			caseName:   "Match the unhold instruction",
+			comment:    "/unhold",
+			expectHold: []bool{false},
+		},
+		{
+			caseName:   "Match several instructions",
+			comment:    "/unhold\n/hold",
+			expectHold: []bool{false, true},
+		},
+		{
+			caseName:   "unmatched instructions with unknown arguments",
+			comment:    "/hold on\n/unhold cancel",
+			expectHold: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			var hold []bool
+			for _, command := range parseCommands(tc.comment) {
+				hold = append(hold, isHold(command))
+			}
+			assert.Equal(t, tc.expectHold, hold)
+		})
+	}
+}
+
+func TestHoldCapture(t *testing.T) {
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "hold actor capture hold command on pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/hold"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: &github.PullRequestLinks{},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "hold actor does not capture issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/hold"),
+					},
+					Issue: &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "hold actor capture new commits pushed to pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("synchronize"),
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "hold actor capture hold label added to pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("labeled"),
+					Label:       &github.Label{Name: github.Ptr("do-not-merge/hold")},
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "hold actor capture hold label removed from pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("unlabeled"),
+					Label:       &github.Label{Name: github.Ptr("do-not-merge/hold")},
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "hold actor does not capture other labels",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("labeled"),
+					Label:       &github.Label{Name: github.Ptr("lgtm")},
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "hold actor does not capture edited pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("edited"),
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			holdActor := &actor{
+				// a noop logger for testing only
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config: config.Default().Actors.Hold,
+			}
+			assert.Equal(t, tc.expect, holdActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestHoldHandler(t *testing.T) {
+	cases := []struct {
+		caseName       string
+		event          any
+		labels         []string
+		expectLabels   []string
+		expectState    string
+		expectReaction bool
+	}{
+		{
+			caseName: "Hold the pull request",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/hold")},
+			},
+			expectLabels:   []string{"do-not-merge/hold"},
+			expectState:    failureState,
+			expectReaction: true,
+		},
+		{
+			caseName: "Cancel the hold",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/hold cancel")},
+			},
+			labels:         []string{"do-not-merge/hold"},
+			expectLabels:   nil,
+			expectState:    successState,
+			expectReaction: true,
+		},
+		{
+			caseName: "Unhold the pull request",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/unhold")},
+			},
+			labels:         []string{"do-not-merge/hold"},
+			expectLabels:   nil,
+			expectState:    successState,
+			expectReaction: true,
+		},
+		{
+			caseName: "The last command wins",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/unhold\n/hold")},
+			},
+			expectLabels:   []string{"do-not-merge/hold"},
+			expectState:    failureState,
+			expectReaction: true,
+		},
+		{
+			caseName: "Publish the hold status on the new head commit",
+			event: github.PullRequestEvent{
+				Action: github.Ptr("synchronize"),
+			},
+			labels:       []string{"do-not-merge/hold"},
+			expectLabels: []string{"do-not-merge/hold"},
+			expectState:  failureState,
+		},
+		{
+			caseName: "Publish the released status on the new head commit",
+			event: github.PullRequestEvent{
+				Action: github.Ptr("synchronize"),
+			},
+			expectLabels: nil,
+			expectState:  successState,
+		},
+		{
+			caseName: "Publish the hold status when the hold label is added",
+			event: github.PullRequestEvent{
+				Action: github.Ptr("labeled"),
+				Label:  &github.Label{Name: github.Ptr("do-not-merge/hold")},
+			},
+			labels:       []string{"do-not-merge/hold"},
+			expectLabels: []string{"do-not-merge/hold"},
+			expectState:  failureState,
+		},
+		{
+			caseName: "Publish the released status when the hold label is removed",
+			event: github.PullRequestEvent{
+				Action: github.Ptr("unlabeled"),
+				Label:  &github.Label{Name: github.Ptr("do-not-merge/hold")},
+			},
+			expectLabels: nil,
+			expectState:  successState,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			var labels []*github.Label
+			for _, label := range tc.labels {
+				labels = append(labels, &github.Label{Name: github.Ptr(label)})
+			}
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr("open"),
+				Labels:           labels,
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+			}
+			gh.PullRequests[1] = &github.PullRequest{
+				Number: github.Ptr(1),
+				State:  github.Ptr("open"),
+				Labels: labels,
+				Head:   &github.PullRequestBranch{SHA: github.Ptr("abc123")},
+			}
+			repo := &github.Repository{FullName: github.Ptr("owner/repo")}
+
+			event := tc.event
+			switch evt := event.(type) {
+			case github.IssueCommentEvent:
+				evt.Issue, evt.Repo = gh.Issues[1], repo
+				event = evt
+			case github.PullRequestEvent:
+				evt.PullRequest, evt.Repo = gh.PullRequests[1], repo
+				event = evt
+			}
+
+			holdActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config: config.Default().Actors.Hold,
+			}
+			assert.True(t, holdActor.Capture(actors.GenericEvent{Event: event}))
+			assert.NoError(t, holdActor.Handler())
+
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			if assert.Len(t, gh.Statuses["abc123"], 1) {
+				status := gh.Statuses["abc123"][0]
+				assert.Equal(t, "actbot/hold", status.GetContext())
+				assert.Equal(t, tc.expectState, status.GetState())
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// The kinds of the changes recorded by a Journal.
+const (
+	LabelAdded        = "label_added"
+	LabelRemoved      = "label_removed"
+	Commented         = "commented"
+	Reacted           = "reacted"
+	Assigned          = "assigned"
+	Unassigned        = "unassigned"
+	StateChanged      = "state_changed"
+	ReviewRequested   = "review_requested"
+	ReviewUnrequested = "review_unrequested"
+	Reviewed          = "reviewed"
+	JobRerun          = "job_rerun"
+	StatusPublished   = "status_published"
+)
+
+// Action is a change made in the repository by an actor.
+type Action struct {
+	// Actor is the name of the actor that made the change,
+	// empty for the changes made by actbot itself, such as the failure replies.
+	Actor string
+
+	// Kind is the kind of change, such as LabelAdded.
+	Kind string
+
+	// Number is the number of the changed issue or pull request,
+	// 0 for the changes of comments, jobs and commits.
+	Number int
+
+	// Value is what was changed, such as the name of the added label.
+	Value string
+}
+
+// Journal records the changes made in the repository through a Client.
+type Journal struct {
+	actor   string
+	actions []Action
+}
+
+// SetActor attributes the following changes to the named actor.
+func (j *Journal) SetActor(name string) {
+	j.actor = name
+}
+
+// Actions returns the recorded changes in the order they were made.
+func (j *Journal) Actions() []Action {
+	return j.actions
+}
+
+// Record returns a Client making the calls with the given one and recording
+// the successful changes in the journal, the reads are not recorded.
+func (j *Journal) Record(ghClient *Client) *Client {
+	return &Client{
+		Issues:       &recordingIssues{IssuesService: ghClient.Issues, journal: j},
+		Reactions:    &recordingReactions{ReactionsService: ghClient.Reactions, journal: j},
+		Checks:       ghClient.Checks,
+		Actions:      &recordingActions{ActionsService: ghClient.Actions, journal: j},
+		PullRequests: &recordingPullRequests{PullRequestsService: ghClient.PullRequests, journal: j},
+		Repositories: &recordingRepositories{RepositoriesService: ghClient.Repositories, journal: j},
+		Search:       ghClient.Search,
+		Git:          ghClient.Git,
+	}
+}
+
+func (j *Journal) add(kind string, number int, values ...string) {
+	for _, value := range values {
+		j.actions = append(j.actions, Action{Actor: j.actor, Kind: kind, Number: number, Value: value})
+	}
+}
+
+type recordingIssues struct {
+	IssuesService
+	journal *Journal
+}
+
+func (s *recordingIssues) Edit(ctx context.Context, owner, repo string, number int, request *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	issue, resp, err := s.IssuesService.Edit(ctx, owner, repo, number, request)
+	if err == nil && request.State != nil {
+		s.journal.add(StateChanged, number, request.GetState())
+	}
+
+	return issue, resp, err
+}
+
+func (s *recordingIssues) CreateComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	created, resp, err := s.IssuesService.CreateComment(ctx, owner, repo, number, comment)
+	if err == nil {
+		s.journal.add(Commented, number, comment.GetBody())
+	}
+
+	return created, resp, err
+}
+
+func (s *recordingIssues) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	added, resp, err := s.IssuesService.AddLabelsToIssue(ctx, owner, repo, number, labels)
+	if err == nil {
+		s.journal.add(LabelAdded, number, labels...)
+	}
+
+	return added, resp, err
+}
+
+func (s *recordingIssues) RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+	resp, err := s.IssuesService.RemoveLabelForIssue(ctx, owner, repo, number, label)
+	if err == nil {
+		s.journal.add(LabelRemoved, number, label)
+	}
+
+	return resp, err
+}
+
+func (s *recordingIssues) AddAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*github.Issue, *github.Response, error) {
+	issue, resp, err := s.IssuesService.AddAssignees(ctx, owner, repo, number, assignees)
+	if err == nil {
+		s.journal.add(Assigned, number, assignees...)
+	}
+
+	return issue, resp, err
+}
+
+func (s *recordingIssues) RemoveAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*github.Issue, *github.Response, error) {
+	issue, resp, err := s.IssuesService.RemoveAssignees(ctx, owner, repo, number, assignees)
+	if err == nil {
+		s.journal.add(Unassigned, number, assignees...)
+	}
+
+	return issue, resp, err
+}
+
+type recordingReactions struct {
+	ReactionsService
+	journal *Journal
+}
+
+func (s *recordingReactions) CreateIssueCommentReaction(ctx context.Context, owner, repo string, id int64, content string) (*github.Reaction, *github.Response, error) {
+	reaction, resp, err := s.ReactionsService.CreateIssueCommentReaction(ctx, owner, repo, id, content)
+	if err == nil {
+		s.journal.add(Reacted, 0, content)
+	}
+
+	return reaction, resp, err
+}
+
+type recordingActions struct {
+	ActionsService
+	journal *Journal
+}
+
+func (s *recordingActions) RerunJobByID(ctx context.Context, owner, repo string, jobID int64) (*github.Response, error) {
+	resp, err := s.ActionsService.RerunJobByID(ctx, owner, repo, jobID)
+	if err == nil {
+		s.journal.add(JobRerun, 0, strconv.FormatInt(jobID, 10))
+	}
+
+	return resp, err
+}
+
+type recordingPullRequests struct {
+	PullRequestsService
+	journal *Journal
+}
+
+func (s *recordingPullRequests) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error) {
+	pr, resp, err := s.PullRequestsService.RequestReviewers(ctx, owner, repo, number, reviewers)
+	if err == nil {
+		s.journal.add(ReviewRequested, number, reviewerNames(owner, reviewers)...)
+	}
+
+	return pr, resp, err
+}
+
+func (s *recordingPullRequests) RemoveReviewers(ctx context.Context, owner, repo string, number int, reviewers github.ReviewersRequest) (*github.Response, error) {
+	resp, err := s.PullRequestsService.RemoveReviewers(ctx, owner, repo, number, reviewers)
+	if err == nil {
+		s.journal.add(ReviewUnrequested, number, reviewerNames(owner, reviewers)...)
+	}
+
+	return resp, err
+}
+
+// reviewerNames returns the logins of the users and the 'org/team' names of the teams of the request.
+func reviewerNames(owner string, reviewers github.ReviewersRequest) []string {
+	names := slices.Clone(reviewers.Reviewers)
+	for _, team := range reviewers.TeamReviewers {
+		names = append(names, owner+"/"+team)
+	}
+
+	return names
+}
+
+func (s *recordingPullRequests) CreateReview(ctx context.Context, owner, repo string, number int, review *github.PullRequestReviewRequest) (*github.PullRequestReview, *github.Response, error) {
+	created, resp, err := s.PullRequestsService.CreateReview(ctx, owner, repo, number, review)
+	if err == nil {
+		s.journal.add(Reviewed, number, review.GetEvent())
+	}
+
+	return created, resp, err
+}
+
+type recordingRepositories struct {
+	RepositoriesService
+	journal *Journal
+}
+
+func (s *recordingRepositories) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	created, resp, err := s.RepositoriesService.CreateStatus(ctx, owner, repo, ref, status)
+	if err == nil {
+		s.journal.add(StatusPublished, 0, fmt.Sprintf("%s=%s", status.GetContext(), status.GetState()))
+	}
+
+	return created, resp, err
+}
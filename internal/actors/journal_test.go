@@ -0,0 +1,75 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+)
+
+func TestJournal(t *testing.T) {
+	gh := fake.New()
+	gh.Labels = []*github.Label{{Name: github.Ptr("area/core")}}
+	gh.Issues[1] = &github.Issue{
+		Number: github.Ptr(1),
+		State:  github.Ptr("open"),
+		Labels: []*github.Label{{Name: github.Ptr(actors.NeedsTriageLabel)}},
+	}
+	gh.PullRequests[1] = &github.PullRequest{Number: github.Ptr(1), State: github.Ptr("open")}
+	gh.Errors["Issues.AddAssignees"] = errors.New("boom")
+
+	journal := &actors.Journal{}
+	ghClient := journal.Record(gh.Client())
+
+	journal.SetActor("AreaLabelerActor")
+	assert.NoError(t, actors.CheckAndAddLabel(ghClient, "owner/repo", 1, "area/core"))
+	assert.NoError(t, actors.RemoveLabelToIssue(ghClient, "owner/repo", 1, actors.NeedsTriageLabel))
+	// failed changes are not recorded
+	_, _, err := ghClient.Issues.AddAssignees(t.Context(), "owner", "repo", 1, []string{"alice"})
+	assert.Error(t, err)
+
+	journal.SetActor("CCActor")
+	_, _, err = ghClient.PullRequests.RequestReviewers(t.Context(), "owner", "repo", 1, github.ReviewersRequest{
+		Reviewers:     []string{"bob"},
+		TeamReviewers: []string{"maintainers"},
+	})
+	assert.NoError(t, err)
+	_, err = ghClient.PullRequests.RemoveReviewers(t.Context(), "owner", "repo", 1, github.ReviewersRequest{
+		TeamReviewers: []string{"maintainers"},
+	})
+	assert.NoError(t, err)
+
+	journal.SetActor("")
+	assert.NoError(t, actors.AddComment(ghClient, "hello", "owner/repo", 1))
+	assert.NoError(t, actors.AddReaction(ghClient, actors.ConfusedReaction, "owner/repo", 1001))
+
+	assert.Equal(t, []actors.Action{
+		{Actor: "AreaLabelerActor", Kind: actors.LabelAdded, Number: 1, Value: "area/core"},
+		{Actor: "AreaLabelerActor", Kind: actors.LabelRemoved, Number: 1, Value: actors.NeedsTriageLabel},
+		{Actor: "CCActor", Kind: actors.ReviewRequested, Number: 1, Value: "bob"},
+		{Actor: "CCActor", Kind: actors.ReviewRequested, Number: 1, Value: "owner/maintainers"},
+		{Actor: "CCActor", Kind: actors.ReviewUnrequested, Number: 1, Value: "owner/maintainers"},
+		{Kind: actors.Commented, Number: 1, Value: "hello"},
+		{Kind: actors.Reacted, Value: actors.ConfusedReaction},
+	}, journal.Actions())
+	// the calls reach the wrapped client
+	assert.Equal(t, []string{"area/core"}, gh.LabelNames(1))
+}
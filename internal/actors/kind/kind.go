@@ -15,8 +15,8 @@
 package kind
 
 import (
-	"regexp"
-	"strings"
+	"errors"
+	"fmt"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
@@ -26,51 +26,54 @@ import (
 
 const (
 	kindLabelerActorName = "KindLabelerActor"
-	kindPrefix           = "kind/"
-)
 
-var (
-	kindRegexp   = regexp.MustCompile(`^/kind\s+(.+)$`)
-	unkindRegexp = regexp.MustCompile(`^/unkind\s+(.+)$`)
+	kindCommand   = "kind"
+	unkindCommand = "unkind"
 )
 
 type actor struct {
-	ghClient *github.Client
+	ghClient *actors.Client
 	logger   *slog.Logger
 
-	event github.IssueCommentEvent
+	// prefix is prepended to the command arguments to build the label names.
+	prefix string
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
 }
 
-func NewLabelerActor(ghClient *github.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+func NewLabelerActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
 	return &actor{
 		ghClient: ghClient,
 		logger:   logger,
+		prefix:   opts.Config.Actors.Kind.Prefix,
 	}
 }
 
 func (a *actor) Handler() error {
 	var (
-		issue   = a.event.GetIssue()
-		repo    = a.event.GetRepo()
-		comment = a.event.GetComment()
-		body    = comment.GetBody()
-		err     error
+		issue = a.event.GetIssue()
+		repo  = a.event.GetRepo()
+		err   error
 	)
-
-	if kindMatch := kindRegexp.FindStringSubmatch(body); kindMatch != nil {
-		labels := strings.Fields(kindMatch[1])
-		for _, label := range labels {
-			label = kindPrefix + label
-			err = actors.CheckAndAddLabel(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
-			if err != nil {
-				return err
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	for _, command := range a.commands {
+		for _, arg := range command.Args {
+			label := a.prefix + arg
+			if command.Name == kindCommand {
+				err = actors.CheckAndAddLabel(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
+			} else {
+				err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
+			}
+			var notFound *actors.LabelNotFoundError
+			if errors.As(err, &notFound) {
+				return &actors.CommandError{
+					Command: fmt.Sprintf("/%s %s", command.Name, arg),
+					Reason:  fmt.Sprintf("the `%s` label does not exist", label),
+					Options: actors.LabelOptions(notFound.Labels, a.prefix),
+				}
 			}
-		}
-	} else if unkindMatch := unkindRegexp.FindStringSubmatch(body); unkindMatch != nil {
-		labels := strings.Fields(unkindMatch[1])
-		for _, label := range labels {
-			label = kindPrefix + label
-			err = actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label)
 			if err != nil {
 				return err
 			}
@@ -103,16 +106,50 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 		return false
 	}
 
-	if kindRegexp.MatchString(commentEvent.Comment.GetBody()) ||
-		unkindRegexp.MatchString(commentEvent.Comment.GetBody()) {
-
-		a.event = commentEvent
-		return true
+	commands := parseCommands(commentEvent.Comment.GetBody())
+	if commands == nil {
+		return false
 	}
+	a.event = commentEvent
+	a.commands = commands
 
-	return false
+	return true
 }
 
 func (a *actor) Name() string {
 	return kindLabelerActorName
 }
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:        kindCommand,
+			Syntax:      "/kind <kind>...",
+			Description: fmt.Sprintf("Adds the `%s<kind>` labels to the issue.", a.prefix),
+			Issues:      true,
+		},
+		{
+			Name:        unkindCommand,
+			Syntax:      "/unkind <kind>...",
+			Description: fmt.Sprintf("Removes the `%s<kind>` labels from the issue.", a.prefix),
+			Issues:      true,
+		},
+	}
+}
+
+// parseCommands returns the kind commands of the comment body,
+// commands without any label are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, kindCommand, unkindCommand) {
+		if len(command.Args) != 0 {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
@@ -0,0 +1,224 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lgtm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+const (
+	lgtmActorName = "LGTMActor"
+
+	lgtmCommand = "lgtm"
+	cancelArg   = "cancel"
+
+	// synchronizeAction is the 'pull_request' event action of new commits pushed to the pull request.
+	synchronizeAction = "synchronize"
+)
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+	config   config.LGTMConfig
+
+	// commentEvent is set when the actor is triggered by a '/lgtm' command,
+	// pullRequestEvent when new commits are pushed to the pull request.
+	commentEvent     *github.IssueCommentEvent
+	pullRequestEvent *github.PullRequestEvent
+	commands         []actors.Command
+}
+
+func NewLGTMActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient: ghClient,
+		logger:   logger,
+		config:   opts.Config.Actors.LGTM,
+	}
+}
+
+func (a *actor) Handler() error {
+	if a.pullRequestEvent != nil {
+		return a.handlePush()
+	}
+
+	var (
+		issue     = a.commentEvent.GetIssue()
+		repo      = a.commentEvent.GetRepo()
+		comment   = a.commentEvent.GetComment()
+		loginUser = comment.GetUser().GetLogin()
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), issue.GetNumber())
+
+	for _, command := range a.commands {
+		if isCancel(command) {
+			if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label); err != nil {
+				return err
+			}
+			a.logger.Infof("remove '%s' label from pr #%d", a.config.Label, issue.GetNumber())
+			continue
+		}
+
+		// the author of the pull request cannot review their own changes
+		if strings.EqualFold(loginUser, issue.GetUser().GetLogin()) {
+			return actors.AddComment(
+				a.ghClient,
+				fmt.Sprintf("@%s you cannot LGTM your own PR.", loginUser),
+				repo.GetFullName(),
+				issue.GetNumber(),
+			)
+		}
+
+		if err := actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.config.Label); err != nil {
+			return err
+		}
+		a.logger.Infof("add '%s' label to pr #%d", a.config.Label, issue.GetNumber())
+
+		if a.config.Review {
+			if err := a.approve(repo.GetFullName(), issue.GetNumber(), loginUser); err != nil {
+				return err
+			}
+			a.logger.Infof("submit an approving review to pr #%d", issue.GetNumber())
+		}
+	}
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return err
+	}
+	a.logger.Infof("add a reaction '%s' to comment %d of pr #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+
+	return nil
+}
+
+// handlePush removes the lgtm label when new commits are pushed, as they have not been reviewed yet.
+func (a *actor) handlePush() error {
+	var (
+		pr   = a.pullRequestEvent.GetPullRequest()
+		repo = a.pullRequestEvent.GetRepo()
+	)
+	a.logger.Infof("actor %s started processing events, pr number: #%d", a.Name(), pr.GetNumber())
+
+	err, has := actors.HasLabel(a.ghClient, repo.GetFullName(), a.config.Label, pr.GetNumber())
+	if err != nil {
+		return err
+	}
+	if !has {
+		return nil
+	}
+
+	if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), pr.GetNumber(), a.config.Label); err != nil {
+		return err
+	}
+	a.logger.Infof("remove '%s' label from pr #%d after new commits are pushed", a.config.Label, pr.GetNumber())
+
+	return actors.AddComment(
+		a.ghClient,
+		fmt.Sprintf("New changes are detected, the `%s` label has been removed.", a.config.Label),
+		repo.GetFullName(),
+		pr.GetNumber(),
+	)
+}
+
+func (a *actor) approve(repoFullName string, number int, loginUser string) error {
+	owner, repoName := actors.GetOwnerRepo(repoFullName)
+	_, _, err := a.ghClient.PullRequests.CreateReview(
+		a.ghClient.Context(),
+		owner,
+		repoName,
+		number,
+		&github.PullRequestReviewRequest{
+			Body:  github.Ptr(fmt.Sprintf("LGTM on behalf of @%s", loginUser)),
+			Event: github.Ptr("APPROVE"),
+		},
+	)
+
+	return err
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	switch evt := event.Event.(type) {
+	case github.IssueCommentEvent:
+		if !evt.Issue.IsPullRequest() || len(evt.Comment.GetBody()) == 0 {
+			return false
+		}
+		if evt.Issue.GetClosedBy() != nil || !evt.Issue.GetClosedAt().IsZero() {
+			return false
+		}
+
+		commands := parseCommands(evt.Comment.GetBody())
+		if commands == nil {
+			return false
+		}
+		a.commentEvent = &evt
+		a.commands = commands
+
+		return true
+	case github.PullRequestEvent:
+		if evt.PullRequest == nil || evt.PullRequest.GetState() == "closed" {
+			return false
+		}
+		if evt.GetAction() != synchronizeAction {
+			return false
+		}
+		a.pullRequestEvent = &evt
+
+		return true
+	default:
+		a.logger.Error("cannot extract event to github.IssueCommentEvent or github.PullRequestEvent, please check event type")
+		return false
+	}
+}
+
+func (a *actor) Name() string {
+	return lgtmActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         lgtmCommand,
+			Syntax:       "/lgtm [cancel]",
+			Description:  fmt.Sprintf("Adds or removes the `%s` label of the pull request.", a.config.Label),
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/lgtm' and '/lgtm cancel' commands of the comment body,
+// commands with other arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, lgtmCommand) {
+		if len(command.Args) == 0 || isCancel(command) {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func isCancel(command actors.Command) bool {
+	return len(command.Args) == 1 && strings.EqualFold(command.Args[0], cancelArg)
+}
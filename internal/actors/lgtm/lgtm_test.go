@@ -0,0 +1,302 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lgtm
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+func TestLGTMCommentBodyMatch(t *testing.T) {
+	cases := []struct {
+		caseName string
+		comment  string
+		expect   []actors.Command
+	}{
+		{
+			caseName: "Match the lgtm instruction",
+			comment:  "/lgtm",
+			expect: []actors.Command{
+				{Name: lgtmCommand},
+			},
+		},
+		{
+			caseName: "Match the lgtm cancel instruction",
+			comment:  "/lgtm cancel",
+			expect: []actors.Command{
+				{Name: lgtmCommand, Args: []string{cancelArg}},
+			},
+		},
+		{
+			caseName: "Match the lgtm instruction among other instructions",
+			comment:  "Looks good to me\n/lgtm\n/retest",
+			expect: []actors.Command{
+				{Name: lgtmCommand},
+			},
+		},
+		{
+			caseName: "unmatched lgtm instruction with unknown arguments",
+			comment:  "/lgtm please",
+			expect:   nil,
+		},
+		{
+			caseName: "unmatched instructions",
+			comment:  "/lgtm1",
+			expect:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, parseCommands(tc.comment))
+		})
+	}
+}
+
+func TestLGTMCapture(t *testing.T) {
+	pullRequestLinks := &github.PullRequestLinks{
+		URL: github.Ptr("https://github.com/example_owner/example_repo/pull/1234567890"),
+	}
+
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "lgtm actor capture lgtm command on pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/lgtm"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: pullRequestLinks,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "lgtm actor does not capture issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/lgtm"),
+					},
+					Issue: &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "lgtm actor does not capture closed pull request",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						Body: github.Ptr[string]("/lgtm"),
+					},
+					Issue: &github.Issue{
+						PullRequestLinks: pullRequestLinks,
+						ClosedAt:         &github.Timestamp{Time: time.Now()},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "lgtm actor capture new commits pushed to pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("synchronize"),
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "lgtm actor does not capture opened pull request",
+			event: actors.GenericEvent{
+				Event: github.PullRequestEvent{
+					Action:      github.Ptr("opened"),
+					PullRequest: &github.PullRequest{State: github.Ptr("open")},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "lgtm actor does not capture issues event",
+			event: actors.GenericEvent{
+				Event: github.IssuesEvent{
+					Action: github.Ptr("opened"),
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			lgtmActor := &actor{
+				// a noop logger for testing only
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			assert.Equal(t, tc.expect, lgtmActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestLGTMHandler(t *testing.T) {
+	var (
+		author   = &github.User{Login: github.Ptr("alice")}
+		reviewer = &github.User{Login: github.Ptr("bob")}
+	)
+
+	cases := []struct {
+		caseName       string
+		event          any
+		labels         []string
+		review         bool
+		expectLabels   []string
+		expectComment  string
+		expectReaction bool
+		expectReview   bool
+	}{
+		{
+			caseName: "Add the lgtm label",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/lgtm"), User: reviewer},
+			},
+			expectLabels:   []string{"lgtm"},
+			expectReaction: true,
+		},
+		{
+			caseName: "Cancel the lgtm label",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/lgtm cancel"), User: reviewer},
+			},
+			labels:         []string{"lgtm"},
+			expectLabels:   nil,
+			expectReaction: true,
+		},
+		{
+			caseName: "Reject the author of the pull request",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/lgtm"), User: author},
+			},
+			expectLabels:  nil,
+			expectComment: "@alice you cannot LGTM your own PR.",
+		},
+		{
+			caseName: "Submit an approving review",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(100)), Body: github.Ptr("/lgtm"), User: reviewer},
+			},
+			review:         true,
+			expectLabels:   []string{"lgtm"},
+			expectReaction: true,
+			expectReview:   true,
+		},
+		{
+			caseName: "Remove the lgtm label when new commits are pushed",
+			event: github.PullRequestEvent{
+				Action: github.Ptr(synchronizeAction),
+			},
+			labels:        []string{"lgtm"},
+			expectLabels:  nil,
+			expectComment: "New changes are detected, the `lgtm` label has been removed.",
+		},
+		{
+			caseName: "Ignore new commits pushed to a pull request without the lgtm label",
+			event: github.PullRequestEvent{
+				Action: github.Ptr(synchronizeAction),
+			},
+			expectLabels: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			var labels []*github.Label
+			for _, label := range tc.labels {
+				labels = append(labels, &github.Label{Name: github.Ptr(label)})
+			}
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr("open"),
+				User:             author,
+				Labels:           labels,
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+			}
+			repo := &github.Repository{FullName: github.Ptr("owner/repo")}
+
+			event := tc.event
+			switch evt := event.(type) {
+			case github.IssueCommentEvent:
+				evt.Issue, evt.Repo = gh.Issues[1], repo
+				event = evt
+			case github.PullRequestEvent:
+				evt.PullRequest = &github.PullRequest{Number: github.Ptr(1), State: github.Ptr("open"), User: author}
+				evt.Repo = repo
+				event = evt
+			}
+
+			lgtmConfig := config.Default().Actors.LGTM
+			lgtmConfig.Review = tc.review
+			lgtmActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config: lgtmConfig,
+			}
+			assert.True(t, lgtmActor.Capture(actors.GenericEvent{Event: event}))
+			assert.NoError(t, lgtmActor.Handler())
+
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			if len(tc.expectComment) != 0 {
+				assert.Equal(t, []string{tc.expectComment}, gh.CommentBodies(1))
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+			if tc.expectReview {
+				if assert.Len(t, gh.Reviews[1], 1) {
+					assert.Equal(t, "APPROVE", gh.Reviews[1][0].GetEvent())
+					assert.Equal(t, "LGTM on behalf of @bob", gh.Reviews[1][0].GetBody())
+				}
+			} else {
+				assert.Empty(t, gh.Reviews[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+const (
+	lifecycleActorName = "LifecycleActor"
+
+	lifecycleCommand       = "lifecycle"
+	removeLifecycleCommand = "remove-lifecycle"
+
+	staleLabel  = "lifecycle/stale"
+	rottenLabel = "lifecycle/rotten"
+	frozenLabel = "lifecycle/frozen"
+
+	day = 24 * time.Hour
+)
+
+// lifecycleLabels maps the arguments of the '/[remove-]lifecycle' commands to their labels.
+var lifecycleLabels = map[string]string{
+	"stale":  staleLabel,
+	"rotten": rottenLabel,
+	"frozen": frozenLabel,
+}
+
+// stage is a step of the lifecycle, reached by the issues and pull requests
+// labeled with the label of the previous stage and inactive for the given days.
+type stage struct {
+	// from is the label of the previous stage, empty for the first stage.
+	from string
+	// to is the label of the stage, empty for the closing stage.
+	to   string
+	days int
+}
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+	config   config.LifecycleConfig
+
+	// repoFullName is the repository running the workflow,
+	// the 'schedule' event does not contain it.
+	repoFullName string
+
+	// now returns the current time, tests replace it to control the inactivity durations.
+	now func() time.Time
+
+	// commentEvent is set when the actor is triggered by a '/[remove-]lifecycle' command,
+	// otherwise the actor is triggered by a 'schedule' event.
+	commentEvent *github.IssueCommentEvent
+	commands     []actors.Command
+}
+
+func NewLifecycleActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient:     ghClient,
+		logger:       logger,
+		config:       opts.Config.Actors.Lifecycle,
+		repoFullName: opts.Repository,
+		now:          time.Now,
+	}
+}
+
+func (a *actor) Handler() error {
+	if a.commentEvent != nil {
+		return a.handleCommands()
+	}
+	a.logger.Infof("actor %s started processing events, repository: %s", a.Name(), a.repoFullName)
+
+	// the later stages go first, so that an issue does not go
+	// through several stages within a single run
+	var errs *multierror.Error
+	for _, s := range a.stages() {
+		issues, err := actors.SearchIssues(a.ghClient, a.query(s))
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			if err := a.promote(issue, s); err != nil {
+				a.logger.Errorf("failed to update the lifecycle of #%d by err: %v", issue.GetNumber(), err)
+				errs = multierror.Append(errs, fmt.Errorf("#%d: %w", issue.GetNumber(), err))
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (a *actor) stages() []stage {
+	return []stage{
+		{from: rottenLabel, days: a.config.CloseDays},
+		{from: staleLabel, to: rottenLabel, days: a.config.RottenDays},
+		{to: staleLabel, days: a.config.StaleDays},
+	}
+}
+
+// query returns the search query of the open issues and pull requests reaching the stage,
+// applying a label updates them, which restarts the inactivity duration of the next stage.
+func (a *actor) query(s stage) string {
+	terms := []string{
+		"repo:" + a.repoFullName,
+		"is:open",
+		"updated:<" + a.now().Add(-time.Duration(s.days)*day).UTC().Format(time.RFC3339),
+	}
+	if len(s.from) != 0 {
+		terms = append(terms, fmt.Sprintf("label:%q", s.from))
+	} else {
+		terms = append(terms, fmt.Sprintf("-label:%q", staleLabel), fmt.Sprintf("-label:%q", rottenLabel))
+	}
+	for _, label := range append([]string{frozenLabel}, a.config.ExemptLabels...) {
+		terms = append(terms, fmt.Sprintf("-label:%q", label))
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// promote moves the issue or pull request to the stage and explains how to opt out.
+func (a *actor) promote(issue *github.Issue, s stage) error {
+	number := issue.GetNumber()
+	if len(s.to) == 0 {
+		return a.close(issue)
+	}
+
+	if len(s.from) != 0 {
+		if err := actors.RemoveLabelToIssue(a.ghClient, a.repoFullName, number, s.from); err != nil {
+			return err
+		}
+	}
+	if err := actors.AddLabelToIssue(a.ghClient, a.repoFullName, number, s.to); err != nil {
+		return err
+	}
+	a.logger.Infof("add '%s' label to #%d", s.to, number)
+
+	content := fmt.Sprintf(
+		"This %s has had no activity for %d days and is now marked as `%s`, it will be closed after %d more days of inactivity.\n\n"+
+			"- Mark it as fresh with `/remove-lifecycle %s`\n"+
+			"- Close it now with `/close`\n"+
+			"- Exempt it from the lifecycle with `/lifecycle frozen`",
+		kindOf(issue), s.days, s.to, a.remainingDays(s), strings.TrimPrefix(s.to, "lifecycle/"),
+	)
+
+	return actors.AddComment(a.ghClient, content, a.repoFullName, number)
+}
+
+// remainingDays returns the days of inactivity left before closing once the stage is reached.
+func (a *actor) remainingDays(s stage) int {
+	if s.to == staleLabel {
+		return a.config.RottenDays + a.config.CloseDays
+	}
+
+	return a.config.CloseDays
+}
+
+func (a *actor) close(issue *github.Issue) error {
+	number := issue.GetNumber()
+	request := &github.IssueRequest{State: github.Ptr("closed")}
+	if !issue.IsPullRequest() {
+		request.StateReason = github.Ptr("not_planned")
+	}
+
+	owner, repoName := actors.GetOwnerRepo(a.repoFullName)
+	if _, _, err := a.ghClient.Issues.Edit(a.ghClient.Context(), owner, repoName, number, request); err != nil {
+		return err
+	}
+	a.logger.Infof("closed rotten #%d", number)
+
+	content := fmt.Sprintf(
+		"This %s has been closed after %d days of inactivity as `%s`, feel free to `/reopen` it if it is still relevant.",
+		kindOf(issue), a.config.CloseDays, rottenLabel,
+	)
+
+	return actors.AddComment(a.ghClient, content, a.repoFullName, number)
+}
+
+// handleCommands applies the '/lifecycle' and '/remove-lifecycle' commands of the comment.
+func (a *actor) handleCommands() error {
+	var (
+		issue   = a.commentEvent.GetIssue()
+		repo    = a.commentEvent.GetRepo()
+		comment = a.commentEvent.GetComment()
+	)
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	for _, command := range a.commands {
+		label := lifecycleLabels[strings.ToLower(command.Args[0])]
+		if command.Name == removeLifecycleCommand {
+			if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label); err != nil {
+				return err
+			}
+			a.logger.Infof("remove '%s' label from #%d", label, issue.GetNumber())
+			continue
+		}
+
+		// an issue is in a single stage of the lifecycle at a time
+		for _, other := range lifecycleLabels {
+			if other == label {
+				continue
+			}
+			if err := actors.RemoveLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), other); err != nil {
+				return err
+			}
+		}
+		if err := actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), label); err != nil {
+			return err
+		}
+		a.logger.Infof("add '%s' label to #%d", label, issue.GetNumber())
+	}
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return err
+	}
+	a.logger.Infof("add a reaction '%s' to comment %d of #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+
+	return nil
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	switch evt := event.Event.(type) {
+	case github.IssueCommentEvent:
+		if len(evt.Comment.GetBody()) == 0 {
+			return false
+		}
+		if evt.Issue.GetClosedBy() != nil || !evt.Issue.GetClosedAt().IsZero() {
+			return false
+		}
+
+		commands := parseCommands(evt.Comment.GetBody())
+		if commands == nil {
+			return false
+		}
+		a.commentEvent = &evt
+		a.commands = commands
+
+		return true
+	case actors.ScheduleEvent:
+		if len(a.repoFullName) == 0 {
+			a.logger.Error("cannot find the repository of the 'schedule' event")
+			return false
+		}
+
+		return true
+	default:
+		a.logger.Error("cannot extract event to github.IssueCommentEvent or actors.ScheduleEvent, please check event type")
+		return false
+	}
+}
+
+func (a *actor) Name() string {
+	return lifecycleActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         lifecycleCommand,
+			Syntax:       "/lifecycle <stale|rotten|frozen>",
+			Description:  "Marks the issue or the pull request, `frozen` ones are never closed for inactivity.",
+			Issues:       true,
+			PullRequests: true,
+		},
+		{
+			Name:         removeLifecycleCommand,
+			Syntax:       "/remove-lifecycle <stale|rotten|frozen>",
+			Description:  "Removes the lifecycle label of the issue or the pull request.",
+			Issues:       true,
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/lifecycle' and '/remove-lifecycle' commands of the comment body
+// with a single known stage argument, such as '/lifecycle frozen', other commands are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, lifecycleCommand, removeLifecycleCommand) {
+		if len(command.Args) != 1 {
+			continue
+		}
+		if _, ok := lifecycleLabels[strings.ToLower(command.Args[0])]; ok {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func kindOf(issue *github.Issue) string {
+	if issue.IsPullRequest() {
+		return "pull request"
+	}
+
+	return "issue"
+}
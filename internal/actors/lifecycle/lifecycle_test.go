@@ -0,0 +1,275 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+)
+
+func TestLifecycleCommentBodyMatch(t *testing.T) {
+	cases := []struct {
+		caseName string
+		comment  string
+		expect   bool
+	}{
+		{
+			caseName: "Match lifecycle frozen instruction",
+			comment:  "/lifecycle frozen",
+			expect:   true,
+		},
+		{
+			caseName: "Match remove-lifecycle stale instruction",
+			comment:  "/remove-lifecycle stale",
+			expect:   true,
+		},
+		{
+			caseName: "Unmatched lifecycle instruction without stage",
+			comment:  "/lifecycle",
+			expect:   false,
+		},
+		{
+			caseName: "Unmatched lifecycle instruction with unknown stage",
+			comment:  "/lifecycle active",
+			expect:   false,
+		},
+		{
+			caseName: "Unmatched instruction",
+			comment:  "/remove-stale",
+			expect:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, parseCommands(tc.comment) != nil)
+		})
+	}
+}
+
+func TestLifecycleQuery(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	cfg := config.Default().Actors.Lifecycle
+	cfg.ExemptLabels = []string{"kind/feature"}
+
+	lifecycleActor := &actor{
+		config:       cfg,
+		repoFullName: "owner/repo",
+		now:          func() time.Time { return now },
+	}
+	stages := lifecycleActor.stages()
+
+	cases := []struct {
+		caseName string
+		stage    stage
+		expect   string
+	}{
+		{
+			caseName: "Close rotten issues",
+			stage:    stages[0],
+			expect:   `repo:owner/repo is:open updated:<2025-05-31T00:00:00Z label:"lifecycle/rotten" -label:"lifecycle/frozen" -label:"kind/feature"`,
+		},
+		{
+			caseName: "Mark stale issues as rotten",
+			stage:    stages[1],
+			expect:   `repo:owner/repo is:open updated:<2025-05-31T00:00:00Z label:"lifecycle/stale" -label:"lifecycle/frozen" -label:"kind/feature"`,
+		},
+		{
+			caseName: "Mark inactive issues as stale",
+			stage:    stages[2],
+			expect: `repo:owner/repo is:open updated:<2025-04-01T00:00:00Z -label:"lifecycle/stale" -label:"lifecycle/rotten" ` +
+				`-label:"lifecycle/frozen" -label:"kind/feature"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, lifecycleActor.query(tc.stage))
+		})
+	}
+}
+
+func TestLifecycleCapture(t *testing.T) {
+	cases := []struct {
+		caseName     string
+		repoFullName string
+		event        actors.GenericEvent
+		expect       bool
+	}{
+		{
+			caseName: "Capture lifecycle command",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/lifecycle frozen")},
+					Issue:   &github.Issue{},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Do not capture command on closed issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/remove-lifecycle rotten")},
+					Issue:   &github.Issue{ClosedAt: &github.Timestamp{Time: time.Now()}},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName:     "Capture schedule event",
+			repoFullName: "owner/repo",
+			event:        actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}},
+			expect:       true,
+		},
+		{
+			caseName: "Do not capture schedule event without repository",
+			event:    actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}},
+			expect:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			lifecycleActor := &actor{
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				repoFullName: tc.repoFullName,
+			}
+			assert.Equal(t, tc.expect, lifecycleActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestLifecycleHandler(t *testing.T) {
+	cases := []struct {
+		caseName string
+		// comment is the comment triggering the actor, a 'schedule' event triggers it when empty.
+		comment string
+		// stage is the index of the stage whose search finds the issue.
+		stage          int
+		labels         []string
+		expectLabels   []string
+		expectState    string
+		expectComment  string
+		expectReaction bool
+	}{
+		{
+			caseName:      "Close the rotten issue",
+			stage:         0,
+			labels:        []string{rottenLabel},
+			expectLabels:  []string{rottenLabel},
+			expectState:   "closed",
+			expectComment: "This issue has been closed after 30 days of inactivity as `lifecycle/rotten`",
+		},
+		{
+			caseName:      "Mark the stale issue as rotten",
+			stage:         1,
+			labels:        []string{staleLabel},
+			expectLabels:  []string{rottenLabel},
+			expectState:   "open",
+			expectComment: "This issue has had no activity for 30 days and is now marked as `lifecycle/rotten`",
+		},
+		{
+			caseName:      "Mark the inactive issue as stale",
+			stage:         2,
+			expectLabels:  []string{staleLabel},
+			expectState:   "open",
+			expectComment: "This issue has had no activity for 90 days and is now marked as `lifecycle/stale`, it will be closed after 60 more days",
+		},
+		{
+			caseName:       "Freeze the stale issue",
+			comment:        "/lifecycle frozen",
+			labels:         []string{staleLabel},
+			expectLabels:   []string{frozenLabel},
+			expectState:    "open",
+			expectReaction: true,
+		},
+		{
+			caseName:       "Remove the stale label",
+			comment:        "/remove-lifecycle stale",
+			labels:         []string{staleLabel},
+			expectLabels:   nil,
+			expectState:    "open",
+			expectReaction: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			var labels []*github.Label
+			for _, label := range tc.labels {
+				labels = append(labels, &github.Label{Name: github.Ptr(label)})
+			}
+			gh.Issues[1] = &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), Labels: labels}
+
+			now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+			lifecycleActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config:       config.Default().Actors.Lifecycle,
+				repoFullName: "owner/repo",
+				now:          func() time.Time { return now },
+			}
+
+			event := actors.GenericEvent{Event: actors.ScheduleEvent{Schedule: "0 0 * * *"}}
+			if len(tc.comment) != 0 {
+				event.Event = github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						ID:   github.Ptr(int64(100)),
+						Body: github.Ptr(tc.comment),
+						User: &github.User{Login: github.Ptr("alice")},
+					},
+					Issue: gh.Issues[1],
+					Repo:  &github.Repository{FullName: github.Ptr("owner/repo")},
+				}
+			} else {
+				query := lifecycleActor.query(lifecycleActor.stages()[tc.stage])
+				gh.SearchResults[query] = []*github.Issue{gh.Issues[1]}
+			}
+			assert.True(t, lifecycleActor.Capture(event))
+			assert.NoError(t, lifecycleActor.Handler())
+
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			assert.Equal(t, tc.expectState, gh.Issues[1].GetState())
+			if len(tc.expectComment) != 0 {
+				if assert.Len(t, gh.Comments[1], 1) {
+					assert.Contains(t, gh.CommentBodies(1)[0], tc.expectComment)
+				}
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
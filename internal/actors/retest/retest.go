@@ -15,36 +15,38 @@
 package retest
 
 import (
-	"context"
 	"fmt"
-	"regexp"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
 )
 
 const (
-	retestActorName = "AssignActor"
+	retestActorName = "RetestActor"
 
 	failedConclusion = "failure"
-)
 
-var retestRegexp = regexp.MustCompile(`^/retest\s*$`)
+	retestCommand = "retest"
+)
 
 type actor struct {
-	ghClient *github.Client
+	ghClient *actors.Client
 	logger   *slog.Logger
+	config   config.RetestConfig
 
-	event github.IssueCommentEvent
+	event    github.IssueCommentEvent
+	commands []actors.Command
 }
 
-func NewRetestActor(ghClient *github.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+func NewRetestActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
 	return &actor{
 		ghClient: ghClient,
 		logger:   logger,
+		config:   opts.Config.Actors.Retest,
 	}
 }
 
@@ -63,22 +65,16 @@ func (a *actor) Handler() error {
 		return err
 	}
 
-	checkRuns, _, err := a.ghClient.Checks.ListCheckRunsForRef(
-		context.Background(),
-		owner,
-		repoName,
-		pr.GetHead().GetSHA(),
-		nil,
-	)
+	checkRuns, err := actors.ListCheckRuns(a.ghClient, repo.GetFullName(), pr.GetHead().GetSHA())
 	if err != nil {
 		return err
 	}
 
 	var failedRuns []*github.CheckRun
-	if checkRuns.CheckRuns == nil {
+	if checkRuns == nil {
 		return nil
 	}
-	for _, run := range checkRuns.CheckRuns {
+	for _, run := range checkRuns {
 		if run.GetConclusion() == failedConclusion {
 			failedRuns = append(failedRuns, run)
 		}
@@ -87,7 +83,7 @@ func (a *actor) Handler() error {
 	if len(failedRuns) == 0 {
 		if err := actors.AddComment(
 			a.ghClient,
-			fmt.Sprintf("@%s %s", loginUser, "The current checks run has all been run successfully and there is no need to rerun it again"),
+			fmt.Sprintf("@%s %s", loginUser, a.config.NoFailedChecksMessage),
 			repo.GetFullName(),
 			issue.GetNumber(),
 		); err != nil {
@@ -101,7 +97,7 @@ func (a *actor) Handler() error {
 		errG := multierror.Append(nil)
 		for _, run := range failedRuns {
 			if _, err := a.ghClient.Actions.RerunJobByID(
-				context.Background(),
+				a.ghClient.Context(),
 				owner,
 				repoName,
 				run.GetID(),
@@ -136,10 +132,12 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 		return false
 	}
 
-	if !retestRegexp.MatchString(commentEvent.Comment.GetBody()) {
+	commands := parseCommands(commentEvent.Comment.GetBody())
+	if commands == nil {
 		return false
 	}
 	a.event = commentEvent
+	a.commands = commands
 
 	return true
 }
@@ -147,3 +145,31 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 func (a *actor) Name() string {
 	return retestActorName
 }
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         retestCommand,
+			Syntax:       "/retest",
+			Description:  "Reruns the failed checks of the pull request.",
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/retest' commands of the comment body,
+// commands with arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, retestCommand) {
+		if len(command.Args) == 0 {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
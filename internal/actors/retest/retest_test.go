@@ -25,6 +25,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
 )
 
 func TestRetestCommentBodyMatch(t *testing.T) {
@@ -43,6 +45,16 @@ func TestRetestCommentBodyMatch(t *testing.T) {
 			comment:  "/retest    ",
 			expect:   true,
 		},
+		{
+			caseName: "Match the retest instruction among other instructions",
+			comment:  "Flaky test, let's try again\n/retest",
+			expect:   true,
+		},
+		{
+			caseName: "unmatched instructions with arguments",
+			comment:  "/retest all",
+			expect:   false,
+		},
 		{
 			caseName: "unmatched instructions",
 			comment:  "/redo",
@@ -52,7 +64,7 @@ func TestRetestCommentBodyMatch(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
-			assert.Equal(t, tc.expect, retestRegexp.MatchString(tc.comment))
+			assert.Equal(t, tc.expect, parseCommands(tc.comment) != nil)
 		})
 	}
 }
@@ -125,7 +137,7 @@ func TestRetestCapture(t *testing.T) {
 			expect: false,
 		},
 		{
-			caseName: "retest actor does not capture unmatched retestRegexp comment body pull request",
+			caseName: "retest actor does not capture unmatched retest command comment body pull request",
 			event: actors.GenericEvent{
 				Event: github.IssueCommentEvent{
 					Comment: &github.IssueComment{
@@ -154,3 +166,84 @@ func TestRetestCapture(t *testing.T) {
 		})
 	}
 }
+
+func TestRetestHandler(t *testing.T) {
+	cases := []struct {
+		caseName       string
+		checkRuns      []*github.CheckRun
+		expectReruns   []int64
+		expectComment  bool
+		expectReaction bool
+	}{
+		{
+			caseName: "Rerun failed check runs",
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Conclusion: github.Ptr("failure")},
+				{ID: github.Ptr(int64(2)), Conclusion: github.Ptr("success")},
+				{ID: github.Ptr(int64(3)), Conclusion: github.Ptr("failure")},
+			},
+			expectReruns:   []int64{1, 3},
+			expectReaction: true,
+		},
+		{
+			caseName: "No failed check runs",
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Conclusion: github.Ptr("success")},
+			},
+			expectComment: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr("open"),
+				PullRequestLinks: &github.PullRequestLinks{},
+			}
+			gh.PullRequests[1] = &github.PullRequest{
+				Number: github.Ptr(1),
+				Head:   &github.PullRequestBranch{SHA: github.Ptr("sha")},
+			}
+			gh.CheckRuns["sha"] = tc.checkRuns
+
+			retestActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				config: config.Default().Actors.Retest,
+			}
+			captured := retestActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						ID:   github.Ptr(int64(100)),
+						Body: github.Ptr("/retest"),
+						User: &github.User{Login: github.Ptr("alice")},
+					},
+					Issue: gh.Issues[1],
+					Repo:  &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+			assert.NoError(t, retestActor.Handler())
+
+			var reruns []int64
+			for _, call := range gh.Called("Actions.RerunJobByID") {
+				reruns = append(reruns, call.Args[0].(int64))
+			}
+			assert.Equal(t, tc.expectReruns, reruns)
+			if tc.expectComment {
+				assert.Equal(t, []string{"@alice " + config.Default().Actors.Retest.NoFailedChecksMessage}, gh.CommentBodies(1))
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.RocketReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+const (
+	stateActorName = "StateActor"
+
+	closeCommand     = "close"
+	reopenCommand    = "reopen"
+	notPlannedArg    = "not-planned"
+	openState        = "open"
+	closedState      = "closed"
+	completedReason  = "completed"
+	notPlannedReason = "not_planned"
+	reopenedReason   = "reopened"
+)
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+
+	event    github.IssueCommentEvent
+	commands []actors.Command
+}
+
+func NewStateActor(ghClient *actors.Client, logger *slog.Logger, _ *actors.Options) actors.Actor {
+	return &actor{
+		ghClient: ghClient,
+		logger:   logger,
+	}
+}
+
+func (a *actor) Handler() error {
+	var (
+		issue   = a.event.GetIssue()
+		repo    = a.event.GetRepo()
+		comment = a.event.GetComment()
+		login   = comment.GetUser().GetLogin()
+	)
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	// the last command of the comment wins
+	command := a.commands[len(a.commands)-1]
+	request := stateRequest(command, issue.IsPullRequest())
+	if request.GetState() == issue.GetState() {
+		content := fmt.Sprintf("@%s this %s is already %s.", login, kindOf(issue), issue.GetState())
+		return actors.AddComment(a.ghClient, content, repo.GetFullName(), issue.GetNumber())
+	}
+
+	owner, repoName := actors.GetOwnerRepo(repo.GetFullName())
+	if _, _, err := a.ghClient.Issues.Edit(a.ghClient.Context(), owner, repoName, issue.GetNumber(), request); err != nil {
+		return err
+	}
+	a.logger.Infof("%s #%d state changed to '%s'", kindOf(issue), issue.GetNumber(), request.GetState())
+
+	if err := actors.AddReaction(a.ghClient, actors.CommendReaction, repo.GetFullName(), comment.GetID()); err != nil {
+		return err
+	}
+	a.logger.Infof("add a reaction '%s' to comment %d of issue #%d", actors.CommendReaction, comment.GetID(), issue.GetNumber())
+
+	return nil
+}
+
+// Capture unlike other actors accepts comments on closed issues and pull requests,
+// since '/reopen' is only meaningful there.
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	genericEvent := event.Event
+	commentEvent, ok := genericEvent.(github.IssueCommentEvent)
+	if !ok {
+		a.logger.Error("cannot extract event to github.IssueCommentEvent, please check event type")
+		return false
+	}
+
+	if commentEvent.Issue == nil || len(commentEvent.Comment.GetBody()) == 0 {
+		return false
+	}
+	// merged pull requests cannot be reopened
+	if !commentEvent.Issue.GetPullRequestLinks().GetMergedAt().IsZero() {
+		return false
+	}
+
+	commands := parseCommands(commentEvent.Comment.GetBody())
+	if commands == nil {
+		return false
+	}
+	a.event = commentEvent
+	a.commands = commands
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return stateActorName
+}
+
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:         closeCommand,
+			Syntax:       "/close [not-planned]",
+			Description:  "Closes the issue or the pull request.",
+			Issues:       true,
+			PullRequests: true,
+		},
+		{
+			Name:         reopenCommand,
+			Syntax:       "/reopen",
+			Description:  "Reopens the issue or the pull request.",
+			Issues:       true,
+			PullRequests: true,
+		},
+	}
+}
+
+// parseCommands returns the '/close', '/close not-planned' and '/reopen' commands of the comment body,
+// commands with other arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, closeCommand, reopenCommand) {
+		switch {
+		case len(command.Args) == 0:
+			commands = append(commands, command)
+		case command.Name == closeCommand && len(command.Args) == 1 && command.Args[0] == notPlannedArg:
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+// stateRequest builds the request changing the state as the command asks,
+// the state reason only applies to issues.
+func stateRequest(command actors.Command, pullRequest bool) *github.IssueRequest {
+	request := &github.IssueRequest{State: github.Ptr(openState)}
+	reason := reopenedReason
+	if command.Name == closeCommand {
+		request.State = github.Ptr(closedState)
+		reason = completedReason
+		if len(command.Args) != 0 {
+			reason = notPlannedReason
+		}
+	}
+	if !pullRequest {
+		request.StateReason = github.Ptr(reason)
+	}
+
+	return request
+}
+
+func kindOf(issue *github.Issue) string {
+	if issue.IsPullRequest() {
+		return "pull request"
+	}
+
+	return "issue"
+}
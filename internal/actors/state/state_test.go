@@ -0,0 +1,284 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+)
+
+func TestStateCommentBodyMatch(t *testing.T) {
+	cases := []struct {
+		caseName string
+		comment  string
+		expect   bool
+	}{
+		{
+			caseName: "Match close instruction",
+			comment:  "/close",
+			expect:   true,
+		},
+		{
+			caseName: "Match close not-planned instruction",
+			comment:  "/close not-planned",
+			expect:   true,
+		},
+		{
+			caseName: "Match reopen instruction",
+			comment:  "/reopen",
+			expect:   true,
+		},
+		{
+			caseName: "Unmatched close instruction with unknown reason",
+			comment:  "/close duplicate",
+			expect:   false,
+		},
+		{
+			caseName: "Unmatched reopen instruction with argument",
+			comment:  "/reopen now",
+			expect:   false,
+		},
+		{
+			caseName: "Unmatched instruction",
+			comment:  "/closed",
+			expect:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, parseCommands(tc.comment) != nil)
+		})
+	}
+}
+
+func TestStateCapture(t *testing.T) {
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "Capture close command on open issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/close")},
+					Issue:   &github.Issue{State: github.Ptr("open")},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Capture reopen command on closed issue",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/reopen")},
+					Issue: &github.Issue{
+						State:    github.Ptr("closed"),
+						ClosedAt: &github.Timestamp{},
+						ClosedBy: &github.User{Login: github.Ptr("alice")},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Do not capture command on merged PR",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/reopen")},
+					Issue: &github.Issue{
+						State: github.Ptr("closed"),
+						PullRequestLinks: &github.PullRequestLinks{
+							MergedAt: &github.Timestamp{Time: time.Now()},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "Do not capture empty comment",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("")},
+					Issue:   &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			stateActor := &actor{
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			assert.Equal(t, tc.expect, stateActor.Capture(tc.event))
+		})
+	}
+}
+
+func TestStateRequest(t *testing.T) {
+	cases := []struct {
+		caseName     string
+		command      actors.Command
+		pullRequest  bool
+		expectState  string
+		expectReason string
+	}{
+		{
+			caseName:     "Close issue as completed",
+			command:      actors.Command{Name: closeCommand},
+			expectState:  "closed",
+			expectReason: "completed",
+		},
+		{
+			caseName:     "Close issue as not planned",
+			command:      actors.Command{Name: closeCommand, Args: []string{notPlannedArg}},
+			expectState:  "closed",
+			expectReason: "not_planned",
+		},
+		{
+			caseName:     "Reopen issue",
+			command:      actors.Command{Name: reopenCommand},
+			expectState:  "open",
+			expectReason: "reopened",
+		},
+		{
+			caseName:    "Close PR without reason",
+			command:     actors.Command{Name: closeCommand},
+			pullRequest: true,
+			expectState: "closed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			request := stateRequest(tc.command, tc.pullRequest)
+			assert.Equal(t, tc.expectState, request.GetState())
+			assert.Equal(t, tc.expectReason, request.GetStateReason())
+		})
+	}
+}
+
+func TestStateHandler(t *testing.T) {
+	cases := []struct {
+		caseName       string
+		comment        string
+		state          string
+		pullRequest    *github.PullRequestLinks
+		expectCaptured bool
+		expectState    string
+		expectReason   string
+		expectComment  string
+		expectReaction bool
+	}{
+		{
+			caseName:       "Close the issue as not planned",
+			comment:        "/close not-planned",
+			state:          openState,
+			expectCaptured: true,
+			expectState:    closedState,
+			expectReason:   notPlannedReason,
+			expectReaction: true,
+		},
+		{
+			caseName:       "Reopen the closed issue",
+			comment:        "/reopen",
+			state:          closedState,
+			expectCaptured: true,
+			expectState:    openState,
+			expectReason:   reopenedReason,
+			expectReaction: true,
+		},
+		{
+			caseName:       "Reply the issue already in the requested state",
+			comment:        "/close",
+			state:          closedState,
+			expectCaptured: true,
+			expectState:    closedState,
+			expectComment:  "@alice this issue is already closed.",
+		},
+		{
+			caseName: "Skip the merged pull request",
+			comment:  "/reopen",
+			state:    closedState,
+			pullRequest: &github.PullRequestLinks{
+				URL:      github.Ptr("https://api.github.com/repos/owner/repo/pulls/1"),
+				MergedAt: &github.Timestamp{Time: time.Now()},
+			},
+			expectCaptured: false,
+			expectState:    closedState,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Issues[1] = &github.Issue{
+				Number:           github.Ptr(1),
+				State:            github.Ptr(tc.state),
+				PullRequestLinks: tc.pullRequest,
+			}
+
+			stateActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			captured := stateActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{
+						ID:   github.Ptr(int64(100)),
+						Body: github.Ptr(tc.comment),
+						User: &github.User{Login: github.Ptr("alice")},
+					},
+					Issue: gh.Issues[1],
+					Repo:  &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.Equal(t, tc.expectCaptured, captured)
+			if captured {
+				assert.NoError(t, stateActor.Handler())
+			}
+
+			assert.Equal(t, tc.expectState, gh.Issues[1].GetState())
+			assert.Equal(t, tc.expectReason, gh.Issues[1].GetStateReason())
+			if len(tc.expectComment) != 0 {
+				assert.Equal(t, []string{tc.expectComment}, gh.CommentBodies(1))
+			} else {
+				assert.Empty(t, gh.Comments[1])
+			}
+			if tc.expectReaction {
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[100])
+			} else {
+				assert.Empty(t, gh.Reactions[100])
+			}
+		})
+	}
+}
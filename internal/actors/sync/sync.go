@@ -15,44 +15,48 @@
 package sync
 
 import (
-	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
 
 	"github.com/ShyunnY/actbot/internal/actors"
-	"github.com/ShyunnY/actbot/internal/options/dingtalk"
 )
 
 const (
 	syncActorName = "SyncActor"
 
-	// Sync Label: GitHub issues that have been synced
-	// to the DingTalk group will be marked with this label.
-	syncLabel = "sync"
+	syncCommand = "sync"
 )
 
+// messageSender sends the messages of the synced issues, it is implemented by dingtalk.DingTalkClient.
+type messageSender interface {
+	SendMessage(issueNumber int, content string) error
+}
+
 type actor struct {
-	ghClient *github.Client
+	ghClient *actors.Client
 	logger   *slog.Logger
 
 	// DingTalk Client
-	dingTalk *dingtalk.DingTalkClient
+	dingTalk messageSender
+
+	// Sync Label: GitHub issues that have been synced
+	// to the DingTalk group will be marked with this label.
+	syncLabel string
 
 	// event is the GitHub issue comment event that triggered this actor.
-	event github.IssueCommentEvent
+	event    github.IssueCommentEvent
+	commands []actors.Command
 }
 
-var syncRegexp = regexp.MustCompile(`^/sync\s*$`)
-
-func NewSyncActor(ghClient *github.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+func NewSyncActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
 	return &actor{
-		dingTalk: opts.DingTalkClient,
-		ghClient: ghClient,
-		logger:   logger,
+		dingTalk:  opts.DingTalkClient,
+		ghClient:  ghClient,
+		logger:    logger,
+		syncLabel: opts.Config.Actors.Sync.Label,
 	}
 }
 
@@ -61,17 +65,17 @@ func (a *actor) Handler() error {
 		repo  = a.event.GetRepo()
 		issue = a.event.GetIssue()
 	)
-	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), repo.GetFullName(), issue.GetNumber())
+	a.logger.Infof("actor %s started processing events, issue number: %s#%d", a.Name(), repo.GetFullName(), issue.GetNumber())
 
-	// check if the issue is already labeled with syncLabel, return.
-	err, has := actors.HasLabel(a.ghClient, repo.GetFullName(), syncLabel, issue.GetNumber())
+	// check if the issue is already labeled with the sync label, return.
+	err, has := actors.HasLabel(a.ghClient, repo.GetFullName(), a.syncLabel, issue.GetNumber())
 	if err != nil {
-		a.logger.Infof("failed to check if issue #%d has label %s, err: %v", issue.GetNumber(), syncLabel, err)
+		a.logger.Infof("failed to check if issue #%d has label %s, err: %v", issue.GetNumber(), a.syncLabel, err)
 		return err
 	}
 
 	if has {
-		a.logger.Infof("issue #%d has label %s, skip sending message", issue.GetNumber(), syncLabel)
+		a.logger.Infof("issue #%d has label %s, skip sending message", issue.GetNumber(), a.syncLabel)
 		return nil
 	}
 
@@ -86,8 +90,8 @@ func (a *actor) Handler() error {
 	}
 
 	// Add sync label to the issue
-	err = actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), syncLabel)
-	a.logger.Warnf("add label %s to issue #%d, err: %v", syncLabel, issue.GetNumber(), err)
+	err = actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), a.syncLabel)
+	a.logger.Warnf("add label %s to issue #%d, err: %v", a.syncLabel, issue.GetNumber(), err)
 	if err != nil {
 		return err
 	}
@@ -120,15 +124,16 @@ func (a *actor) Capture(event actors.GenericEvent) bool {
 		return false
 	}
 
-	// Check if the comment body matches the `/sync` command.
-	matches := syncRegexp.FindAllStringSubmatch(comment.GetBody(), -1)
-	if matches == nil {
-		// the comment does not match the `/sync` command.
+	// Check if the comment body has the `/sync` command.
+	commands := parseCommands(comment.GetBody())
+	if commands == nil {
+		// the comment does not have the `/sync` command.
 		return false
 	}
 
 	// If the command is `/sync`, set the event msg.
 	a.event = commentEvent
+	a.commands = commands
 
 	return true
 }
@@ -137,14 +142,42 @@ func (a *actor) Name() string {
 	return syncActorName
 }
 
+func (a *actor) Commands() []actors.Command {
+	return a.commands
+}
+
+func (a *actor) Help() []actors.CommandHelp {
+	return []actors.CommandHelp{
+		{
+			Name:        syncCommand,
+			Syntax:      "/sync",
+			Description: "Shares the issue with the DingTalk group of the maintainers.",
+			Issues:      true,
+		},
+	}
+}
+
+// parseCommands returns the `/sync` commands of the comment body,
+// commands with arguments are ignored.
+func parseCommands(body string) []actors.Command {
+	var commands []actors.Command
+	for _, command := range actors.ParseCommands(body, syncCommand) {
+		if len(command.Args) == 0 {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
 // buildMessageContent builds the message content to be sent to DingTalk.
 // The content of the file message is in markdown format, and it is helpful
 // for maintainers to select and deal with issues by displaying as much information as possible.
-func buildMessageContent(ghClient *github.Client, issue *github.Issue, repo *github.Repository) (string, error) {
+func buildMessageContent(ghClient *actors.Client, issue *github.Issue, repo *github.Repository) (string, error) {
 	owner, repoName := actors.GetOwnerRepo(repo.GetFullName())
 
 	// 获取标签
-	labels, _, err := ghClient.Issues.ListLabelsByIssue(context.Background(), owner, repoName, issue.GetNumber(), nil)
+	labels, err := actors.ListIssueLabels(ghClient, repo.GetFullName(), issue.GetNumber())
 	if err != nil {
 		return "", fmt.Errorf("failed to get labels for issue #%d: %w", issue.GetNumber(), err)
 	}
@@ -161,7 +194,7 @@ func buildMessageContent(ghClient *github.Client, issue *github.Issue, repo *git
 	}
 
 	// 获取当前问题的标题
-	currentIssue, _, err := ghClient.Issues.Get(context.Background(), owner, repoName, issue.GetNumber())
+	currentIssue, _, err := ghClient.Issues.Get(ghClient.Context(), owner, repoName, issue.GetNumber())
 	if err != nil {
 		return "", fmt.Errorf("failed to get issue #%d: %w", issue.GetNumber(), err)
 	}
@@ -30,6 +30,7 @@
 package sync
 
 import (
+	"errors"
 	"io"
 	"testing"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
 )
 
 func TestSyncerCommentBodyMatch(t *testing.T) {
@@ -57,6 +59,11 @@ func TestSyncerCommentBodyMatch(t *testing.T) {
 			comment:  "/sync    ",
 			expect:   true,
 		},
+		{
+			caseName: "Match sync instruction among other instructions",
+			comment:  "/kind feature\n/sync",
+			expect:   true,
+		},
 		{
 			caseName: "Unmatched instruction",
 			comment:  "/resync",
@@ -66,7 +73,7 @@ func TestSyncerCommentBodyMatch(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
-			assert.Equal(t, tc.expect, syncRegexp.MatchString(tc.comment))
+			assert.Equal(t, tc.expect, parseCommands(tc.comment) != nil)
 		})
 	}
 }
@@ -146,3 +153,93 @@ func TestSyncerCapture(t *testing.T) {
 		})
 	}
 }
+
+// recordingSender records the sent messages instead of sending them to DingTalk.
+type recordingSender struct {
+	messages map[int]string
+	err      error
+}
+
+func (s *recordingSender) SendMessage(issueNumber int, content string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages[issueNumber] = content
+
+	return nil
+}
+
+func TestSyncerHandler(t *testing.T) {
+	cases := []struct {
+		caseName      string
+		labels        []string
+		sendErr       error
+		expectLabels  []string
+		expectMessage bool
+		expectErr     bool
+	}{
+		{
+			caseName:      "Sync the issue",
+			labels:        []string{"kind/bug"},
+			expectLabels:  []string{"kind/bug", "sync"},
+			expectMessage: true,
+		},
+		{
+			caseName:     "Skip the synced issue",
+			labels:       []string{"sync"},
+			expectLabels: []string{"sync"},
+		},
+		{
+			caseName:     "Do not label the issue when the message is not sent",
+			sendErr:      errors.New("unavailable"),
+			expectLabels: nil,
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Issues[1] = &github.Issue{
+				Number: github.Ptr(1),
+				Title:  github.Ptr("Crash on startup"),
+				State:  github.Ptr("open"),
+			}
+			for _, name := range tc.labels {
+				gh.Issues[1].Labels = append(gh.Issues[1].Labels, &github.Label{Name: github.Ptr(name)})
+			}
+			sender := &recordingSender{messages: make(map[int]string), err: tc.sendErr}
+
+			syncActor := &actor{
+				ghClient: gh.Client(),
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+				dingTalk:  sender,
+				syncLabel: "sync",
+			}
+			captured := syncActor.Capture(actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Comment: &github.IssueComment{Body: github.Ptr("/sync")},
+					Issue:   gh.Issues[1],
+					Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			})
+			assert.True(t, captured)
+
+			err := syncActor.Handler()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectLabels, gh.LabelNames(1))
+			if tc.expectMessage {
+				assert.Contains(t, sender.messages[1], "Crash on startup")
+				assert.Contains(t, sender.messages[1], "kind/bug")
+			} else {
+				assert.Empty(t, sender.messages)
+			}
+		})
+	}
+}
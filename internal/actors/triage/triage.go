@@ -0,0 +1,96 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triage
+
+import (
+	"slices"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+const (
+	triageActorName = "TriageActor"
+)
+
+// triageActions are the 'issues' event actions after which
+// an issue has to be looked at by the maintainers again. The issue of a
+// 'transferred' event has left the repository, the destination repository
+// receives an 'opened' event for it instead.
+var triageActions = []string{"opened", "reopened"}
+
+type actor struct {
+	ghClient *actors.Client
+	logger   *slog.Logger
+
+	// defaultLabels are applied together with the 'needs-triage' label.
+	defaultLabels []string
+
+	event github.IssuesEvent
+}
+
+func NewTriageActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	return &actor{
+		ghClient:      ghClient,
+		logger:        logger,
+		defaultLabels: opts.Config.Actors.Triage.Labels,
+	}
+}
+
+func (a *actor) Handler() error {
+	var (
+		issue = a.event.GetIssue()
+		repo  = a.event.GetRepo()
+	)
+	a.logger.Infof("actor %s started processing events, issue number: #%d", a.Name(), issue.GetNumber())
+
+	labels := []string{actors.NeedsTriageLabel}
+	for _, label := range a.defaultLabels {
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	if err := actors.AddLabelToIssue(a.ghClient, repo.GetFullName(), issue.GetNumber(), labels...); err != nil {
+		return err
+	}
+	a.logger.Infof("add labels %v to issue #%d", labels, issue.GetNumber())
+
+	return nil
+}
+
+func (a *actor) Capture(event actors.GenericEvent) bool {
+	issuesEvent, ok := event.Event.(github.IssuesEvent)
+	if !ok {
+		a.logger.Error("cannot extract event to github.IssuesEvent, please check event type")
+		return false
+	}
+
+	if issuesEvent.Issue == nil || issuesEvent.Issue.IsPullRequest() {
+		return false
+	}
+	if !slices.Contains(triageActions, issuesEvent.GetAction()) {
+		return false
+	}
+	a.event = issuesEvent
+
+	return true
+}
+
+func (a *actor) Name() string {
+	return triageActorName
+}
@@ -0,0 +1,98 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triage
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+func TestTriageCapture(t *testing.T) {
+	cases := []struct {
+		caseName string
+		event    actors.GenericEvent
+		expect   bool
+	}{
+		{
+			caseName: "Capture opened issue",
+			event: actors.GenericEvent{
+				Event: github.IssuesEvent{
+					Action: github.Ptr("opened"),
+					Issue:  &github.Issue{},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Capture reopened issue",
+			event: actors.GenericEvent{
+				Event: github.IssuesEvent{
+					Action: github.Ptr("reopened"),
+					Issue:  &github.Issue{},
+				},
+			},
+			expect: true,
+		},
+		{
+			caseName: "Do not capture transferred issue",
+			event: actors.GenericEvent{
+				Event: github.IssuesEvent{
+					Action: github.Ptr("transferred"),
+					Issue:  &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "Do not capture labeled issue",
+			event: actors.GenericEvent{
+				Event: github.IssuesEvent{
+					Action: github.Ptr("labeled"),
+					Issue:  &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+		{
+			caseName: "Do not capture issue comment event",
+			event: actors.GenericEvent{
+				Event: github.IssueCommentEvent{
+					Action: github.Ptr("created"),
+					Issue:  &github.Issue{},
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			triageActor := &actor{
+				// a noop logger for testing only
+				logger: slog.NewWithConfig(func(l *slog.Logger) {
+					l.PushHandler(handler.NewIOWriterHandler(io.Discard, slog.AllLevels))
+				}),
+			}
+			assert.Equal(t, tc.expect, triageActor.Capture(tc.event))
+		})
+	}
+}
@@ -15,7 +15,12 @@
 package actors
 
 import (
+	"fmt"
+
+	"github.com/ShyunnY/actbot/internal/config"
 	"github.com/ShyunnY/actbot/internal/options/dingtalk"
+	"github.com/ShyunnY/actbot/internal/owners"
+	"github.com/ShyunnY/actbot/internal/permission"
 )
 
 // Constant definitions related to GitHub labels
@@ -28,6 +33,10 @@ const (
 	NeedsTriageLabel = "needs-triage"
 )
 
+// DefaultBotLogin is the login of the bot behind the GITHUB_TOKEN of the workflow,
+// the comments of the actors are written by it unless another token is used.
+const DefaultBotLogin = "github-actions[bot]"
+
 // Constant definitions related to GitHub comment reaction
 const (
 	// CommendReaction The value of the "+1 👍" reaction has been defined
@@ -35,6 +44,9 @@ const (
 
 	// RocketReaction The value of the "rocket 🚀" reaction has been defined
 	RocketReaction = "rocket"
+
+	// ConfusedReaction The value of the "confused 😕" reaction has been defined
+	ConfusedReaction = "confused"
 )
 
 type Actor interface {
@@ -45,12 +57,98 @@ type Actor interface {
 	Name() string
 }
 
+// CommandActor is an Actor triggered by slash commands in comments, the commenter
+// is required to have the role configured for every captured command before the
+// Handler runs.
+type CommandActor interface {
+	Actor
+
+	// Commands returns the commands captured from the comment.
+	Commands() []Command
+}
+
+// CommandHelp describes a command listed by the '/help' command.
+type CommandHelp struct {
+	// Name is the command name without the leading slash, such as "area",
+	// it is used to look up the role required to run the command in the config.
+	Name string
+
+	// Syntax is the usage of the command, such as "/area <area>...".
+	Syntax string
+
+	// Description explains what the command does.
+	Description string
+
+	// Role overrides the role configured for the command name, when the
+	// syntax requires another role, such as assigning other users.
+	Role permission.Role
+
+	// Issues reports whether the command can be run on issues.
+	Issues bool
+
+	// PullRequests reports whether the command can be run on pull requests.
+	PullRequests bool
+}
+
+// DocumentedActor is a CommandActor describing the commands it handles,
+// they are listed by the '/help' command when the actor is enabled.
+type DocumentedActor interface {
+	CommandActor
+
+	// Help returns the commands handled by the actor.
+	Help() []CommandHelp
+}
+
+// CommandError is returned by the Handler of an actor when a command cannot be applied
+// because of its arguments, the commenter is told the reason and the valid options.
+type CommandError struct {
+	// Command is the failed command as written by the commenter, such as "/area foo".
+	Command string
+
+	// Reason explains why the command failed.
+	Reason string
+
+	// Options are the valid arguments of the command, if any.
+	Options []string
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Command, e.Reason)
+}
+
 type GenericEvent struct {
+	// Name is the name of the GitHub event that triggered the workflow,
+	// such as 'issue_comment' or 'pull_request_target'.
+	Name string
+
 	// This represents the actual GitHub events
 	Event any
 }
 
+// ScheduleEvent is the payload of the 'schedule' event, GitHub does not
+// send any repository information with it.
+type ScheduleEvent struct {
+	// Schedule is the cron expression that triggered the workflow.
+	Schedule string `json:"schedule"`
+
+	// Workflow is the path of the workflow file.
+	Workflow string `json:"workflow"`
+}
+
 // Options GitHub Actor extension options.
 type Options struct {
 	*dingtalk.DingTalkClient
+
+	// Config is the repository config, actors read their settings from it.
+	Config *config.Config
+
+	// Owners are the OWNERS files of the repository, nil if they could not be loaded.
+	Owners *owners.Owners
+
+	// Permissions resolves the roles of users in the repository.
+	Permissions *permission.Checker
+
+	// Repository is the full name of the repository running the workflow,
+	// actors triggered by events without repository information use it.
+	Repository string
 }
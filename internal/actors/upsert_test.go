@@ -0,0 +1,82 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+)
+
+func TestUpsertComment(t *testing.T) {
+	const marker = "<!-- actbot:test -->"
+	var (
+		bot  = &github.User{Login: github.Ptr(fake.BotLogin), Type: github.Ptr("Bot")}
+		user = &github.User{Login: github.Ptr("mallory"), Type: github.Ptr("User")}
+		pat  = &github.User{Login: github.Ptr("actbot-user"), Type: github.Ptr("User")}
+	)
+
+	cases := []struct {
+		caseName     string
+		login        string
+		comments     []*github.IssueComment
+		expectBodies []string
+	}{
+		{
+			caseName:     "Create the comment",
+			expectBodies: []string{marker + "\nstatus"},
+		},
+		{
+			caseName: "Edit the comment of the bot",
+			comments: []*github.IssueComment{
+				{ID: github.Ptr(int64(1)), User: bot, Body: github.Ptr(marker + "\nprevious")},
+			},
+			expectBodies: []string{marker + "\nstatus"},
+		},
+		{
+			caseName: "Edit the comment of the user behind a personal access token",
+			login:    "actbot-user",
+			comments: []*github.IssueComment{
+				{ID: github.Ptr(int64(1)), User: bot, Body: github.Ptr(marker + "\nprevious")},
+				{ID: github.Ptr(int64(2)), User: pat, Body: github.Ptr(marker + "\nprevious")},
+			},
+			expectBodies: []string{marker + "\nprevious", marker + "\nstatus"},
+		},
+		{
+			caseName: "Ignore the marker in the comment of a user",
+			comments: []*github.IssueComment{
+				{ID: github.Ptr(int64(1)), User: user, Body: github.Ptr(marker + "\nprevious")},
+			},
+			expectBodies: []string{marker + "\nprevious", marker + "\nstatus"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			if len(tc.login) != 0 {
+				gh.Login = tc.login
+			}
+			gh.Comments[1] = tc.comments
+
+			assert.NoError(t, actors.UpsertComment(gh.Client(), "owner/repo", 1, marker, "status"))
+			assert.Equal(t, tc.expectBodies, gh.CommentBodies(1))
+		})
+	}
+}
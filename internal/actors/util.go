@@ -15,17 +15,19 @@
 package actors
 
 import (
-	"context"
 	"fmt"
+	"net/http"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/google/go-github/v72/github"
 )
 
-func AddComment(ghClient *github.Client, content, fullName string, issueNumber int) error {
+func AddComment(ghClient *Client, content, fullName string, issueNumber int) error {
 	owner, repo := GetOwnerRepo(fullName)
 	if _, _, err := ghClient.Issues.CreateComment(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issueNumber,
@@ -39,10 +41,10 @@ func AddComment(ghClient *github.Client, content, fullName string, issueNumber i
 	return nil
 }
 
-func AddLabelToIssue(ghClient *github.Client, fullName string, issueNumber int, label ...string) error {
+func AddLabelToIssue(ghClient *Client, fullName string, issueNumber int, label ...string) error {
 	owner, repo := GetOwnerRepo(fullName)
 	if _, _, err := ghClient.Issues.AddLabelsToIssue(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issueNumber,
@@ -54,11 +56,11 @@ func AddLabelToIssue(ghClient *github.Client, fullName string, issueNumber int,
 	return nil
 }
 
-func RemoveLabelToIssue(ghClient *github.Client, fullName string, issueNumber int, label string) error {
+func RemoveLabelToIssue(ghClient *Client, fullName string, issueNumber int, label string) error {
 	owner, repo := GetOwnerRepo(fullName)
 
 	issue, _, err := ghClient.Issues.Get(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issueNumber,
@@ -81,7 +83,7 @@ func RemoveLabelToIssue(ghClient *github.Client, fullName string, issueNumber in
 	}
 
 	if _, err := ghClient.Issues.RemoveLabelForIssue(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issueNumber,
@@ -93,10 +95,10 @@ func RemoveLabelToIssue(ghClient *github.Client, fullName string, issueNumber in
 	return nil
 }
 
-func AddReaction(ghClient *github.Client, reaction, fullName string, issueCommentID int64) error {
+func AddReaction(ghClient *Client, reaction, fullName string, issueCommentID int64) error {
 	owner, repo := GetOwnerRepo(fullName)
 	if _, _, err := ghClient.Reactions.CreateIssueCommentReaction(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issueCommentID,
@@ -108,10 +110,10 @@ func AddReaction(ghClient *github.Client, reaction, fullName string, issueCommen
 	return nil
 }
 
-func GetPRFromIssue(ghClient *github.Client, fullName string, issue *github.Issue) (*github.PullRequest, error) {
+func GetPRFromIssue(ghClient *Client, fullName string, issue *github.Issue) (*github.PullRequest, error) {
 	owner, repo := GetOwnerRepo(fullName)
 	pullRequest, _, err := ghClient.PullRequests.Get(
-		context.Background(),
+		ghClient.Context(),
 		owner,
 		repo,
 		issue.GetNumber(),
@@ -131,28 +133,59 @@ func GetOwnerRepo(fullName string) (owner, repo string) {
 	return
 }
 
-func CheckAndAddLabel(ghClient *github.Client, repoFullName string, issueNumber int, label string) error {
+// LabelNotFoundError is returned when a label does not exist in the repository.
+type LabelNotFoundError struct {
+	// Label is the name of the missing label.
+	Label string
+
+	// Labels are the names of the labels of the repository.
+	Labels []string
+}
+
+func (e *LabelNotFoundError) Error() string {
+	return fmt.Sprintf("label '%s' does not exist", e.Label)
+}
+
+// LabelOptions returns the sorted names of the labels starting with the prefix,
+// without the prefix, such as the valid arguments of the '/area' command.
+func LabelOptions(labels []string, prefix string) []string {
+	var options []string
+	for _, label := range labels {
+		if option, ok := strings.CutPrefix(label, prefix); ok && len(option) != 0 {
+			options = append(options, option)
+		}
+	}
+	sort.Strings(options)
+
+	return options
+}
+
+func CheckAndAddLabel(ghClient *Client, repoFullName string, issueNumber int, label string) error {
 	owner, repoName := GetOwnerRepo(repoFullName)
 
 	// Get all labels for the repository
-	labels, _, err := ghClient.Issues.ListLabels(context.Background(), owner, repoName, nil)
+	labels, err := ListAll(func(opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return ghClient.Issues.ListLabels(ghClient.Context(), owner, repoName, opts)
+	})
 	if err != nil {
 		return err
 	}
 
 	// Check label exists.
 	labelExists := false
+	names := make([]string, 0, len(labels))
 	for _, l := range labels {
 		if l.GetName() == label {
 			labelExists = true
 			break
 		}
+		names = append(names, l.GetName())
 	}
 
 	if !labelExists {
-		// if label does not exist, log and return error,
-		// maintainers are not informed in the form of comments.
-		return fmt.Errorf("label '%s' does not exist", label)
+		// if label does not exist, return the labels of the repository,
+		// so that the actors can tell the commenter the valid ones.
+		return &LabelNotFoundError{Label: label, Labels: names}
 	}
 
 	// Add label to the issue.
@@ -167,9 +200,8 @@ func CheckAndAddLabel(ghClient *github.Client, repoFullName string, issueNumber
 // HasLabel Check if the issue has a label specified
 // If has, return nil, true
 // If not has, return nil, false
-func HasLabel(ghClient *github.Client, repoFullName, labelName string, issueNumber int) (error, bool) {
-	owner, repo := GetOwnerRepo(repoFullName)
-	labels, _, err := ghClient.Issues.ListLabelsByIssue(context.Background(), owner, repo, issueNumber, nil)
+func HasLabel(ghClient *Client, repoFullName, labelName string, issueNumber int) (error, bool) {
+	labels, err := ListIssueLabels(ghClient, repoFullName, issueNumber)
 	if err != nil {
 		return err, false
 	}
@@ -186,3 +218,184 @@ func HasLabel(ghClient *github.Client, repoFullName, labelName string, issueNumb
 
 	return nil, false
 }
+
+// ListIssueLabels returns all the labels of the issue or pull request.
+func ListIssueLabels(ghClient *Client, repoFullName string, issueNumber int) ([]*github.Label, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	return ListAll(func(opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return ghClient.Issues.ListLabelsByIssue(ghClient.Context(), owner, repo, issueNumber, opts)
+	})
+}
+
+// GetFileContent returns the content of the file at the given path on the default branch of the repository.
+// If the file does not exist, return nil, nil
+func GetFileContent(ghClient *Client, repoFullName, path string) ([]byte, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+	file, _, resp, err := ghClient.Repositories.GetContents(ghClient.Context(), owner, repo, path, nil)
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case file == nil:
+		return nil, fmt.Errorf("path '%s' is not a file", path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
+
+// ListFilesByName returns the paths of the files with the given name in the tree of the default branch.
+func ListFilesByName(ghClient *Client, repoFullName, name string) ([]string, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+	tree, _, err := ghClient.Git.GetTree(ghClient.Context(), owner, repo, "HEAD", true)
+	if err != nil {
+		return nil, err
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("the tree of repository '%s' is too large to be listed", repoFullName)
+	}
+
+	var paths []string
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" && path.Base(entry.GetPath()) == name {
+			paths = append(paths, entry.GetPath())
+		}
+	}
+
+	return paths, nil
+}
+
+// ListAll calls list for every page of a paginated GitHub API, following
+// Response.NextPage until the last page, and returns the items of all the pages.
+func ListAll[T any](list func(opts *github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	var (
+		items []T
+		opts  = &github.ListOptions{PerPage: 100}
+	)
+	for {
+		page, resp, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if resp == nil || resp.NextPage == 0 {
+			return items, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListComments returns all the comments of the issue or pull request, oldest first.
+func ListComments(ghClient *Client, repoFullName string, issueNumber int) ([]*github.IssueComment, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	return ListAll(func(opts *github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+		return ghClient.Issues.ListComments(ghClient.Context(), owner, repo, issueNumber, &github.IssueListCommentsOptions{ListOptions: *opts})
+	})
+}
+
+// ListOpenIssuesByAssignee returns the open issues of the repository assigned to the user,
+// pull requests are excluded. The '*' login matches the issues assigned to anyone.
+func ListOpenIssuesByAssignee(ghClient *Client, repoFullName, login string) ([]*github.Issue, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	issues, err := ListAll(func(opts *github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		return ghClient.Issues.ListByRepo(ghClient.Context(), owner, repo, &github.IssueListByRepoOptions{
+			Assignee:    login,
+			State:       "open",
+			ListOptions: *opts,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*github.Issue
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			ret = append(ret, issue)
+		}
+	}
+
+	return ret, nil
+}
+
+// SearchIssues returns all the issues and pull requests matching the search query,
+// the search API returns at most 1000 results.
+func SearchIssues(ghClient *Client, query string) ([]*github.Issue, error) {
+	return ListAll(func(opts *github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		result, resp, err := ghClient.Search.Issues(ghClient.Context(), query, &github.SearchOptions{ListOptions: *opts})
+		if result == nil {
+			return nil, resp, err
+		}
+		return result.Issues, resp, err
+	})
+}
+
+// ListTimeline returns all the timeline events of the issue.
+func ListTimeline(ghClient *Client, repoFullName string, issueNumber int) ([]*github.Timeline, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	return ListAll(func(opts *github.ListOptions) ([]*github.Timeline, *github.Response, error) {
+		return ghClient.Issues.ListIssueTimeline(ghClient.Context(), owner, repo, issueNumber, opts)
+	})
+}
+
+// ListPRFiles returns all the files changed by the pull request.
+func ListPRFiles(ghClient *Client, repoFullName string, prNumber int) ([]*github.CommitFile, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	return ListAll(func(opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+		return ghClient.PullRequests.ListFiles(ghClient.Context(), owner, repo, prNumber, opts)
+	})
+}
+
+// ListCheckRuns returns all the check runs of the git reference.
+func ListCheckRuns(ghClient *Client, repoFullName, ref string) ([]*github.CheckRun, error) {
+	owner, repo := GetOwnerRepo(repoFullName)
+
+	return ListAll(func(opts *github.ListOptions) ([]*github.CheckRun, *github.Response, error) {
+		result, resp, err := ghClient.Checks.ListCheckRunsForRef(ghClient.Context(), owner, repo, ref, &github.ListCheckRunsOptions{ListOptions: *opts})
+		if result == nil {
+			return nil, resp, err
+		}
+		return result.CheckRuns, resp, err
+	})
+}
+
+// UpsertComment keeps a single bot comment up to date on the issue: the comment of the bot that
+// contains the marker, usually an HTML comment, is edited, otherwise a new comment is created.
+func UpsertComment(ghClient *Client, repoFullName string, issueNumber int, marker, content string) error {
+	owner, repo := GetOwnerRepo(repoFullName)
+	comments, err := ListComments(ghClient, repoFullName, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	body := marker + "\n" + content
+	for _, comment := range comments {
+		if !IsBotComment(ghClient, comment) || !strings.Contains(comment.GetBody(), marker) {
+			continue
+		}
+		if comment.GetBody() == body {
+			return nil
+		}
+
+		_, _, err := ghClient.Issues.EditComment(ghClient.Context(), owner, repo, comment.GetID(), &github.IssueComment{Body: &body})
+		return err
+	}
+
+	return AddComment(ghClient, body, repoFullName, issueNumber)
+}
+
+// IsBotComment reports whether the comment was written by the user behind the token of the client,
+// the markers found in the comments of other users must not be trusted.
+func IsBotComment(ghClient *Client, comment *github.IssueComment) bool {
+	return strings.EqualFold(comment.GetUser().GetLogin(), ghClient.BotLogin())
+}
@@ -0,0 +1,263 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// paginate serves the items split into pages of the given size, linking every page to the next one
+// as GitHub does, wrap wraps the items of a page for the APIs that do not return a bare array.
+func paginate[T any](t *testing.T, items []T, size int, wrap func(page []T) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); len(p) != 0 {
+			var err error
+			page, err = strconv.Atoi(p)
+			assert.NoError(t, err)
+		}
+
+		start, end := (page-1)*size, page*size
+		if end < len(items) {
+			next := *r.URL
+			query := next.Query()
+			query.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+		} else {
+			end = len(items)
+		}
+
+		var body any = items[start:end]
+		if wrap != nil {
+			body = wrap(items[start:end])
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	}
+}
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+	assert.NoError(t, err)
+
+	return NewClient(ghClient)
+}
+
+func labels(names ...string) []*github.Label {
+	var ret []*github.Label
+	for _, name := range names {
+		ret = append(ret, &github.Label{Name: github.Ptr(name)})
+	}
+
+	return ret
+}
+
+func TestListAll(t *testing.T) {
+	cases := []struct {
+		caseName string
+		items    int
+		pageSize int
+		expect   int
+	}{
+		{
+			caseName: "Single page",
+			items:    3,
+			pageSize: 5,
+			expect:   3,
+		},
+		{
+			caseName: "Several pages",
+			items:    7,
+			pageSize: 3,
+			expect:   7,
+		},
+		{
+			caseName: "No items",
+			items:    0,
+			pageSize: 3,
+			expect:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			var names []string
+			for i := range tc.items {
+				names = append(names, fmt.Sprintf("label-%d", i))
+			}
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/v3/repos/owner/repo/labels", paginate(t, labels(names...), tc.pageSize, nil))
+			ghClient := newTestClient(t, mux)
+
+			items, err := ListAll(func(opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+				return ghClient.Issues.ListLabels(t.Context(), "owner", "repo", opts)
+			})
+			assert.NoError(t, err)
+			assert.Len(t, items, tc.expect)
+		})
+	}
+}
+
+func TestCheckAndAddLabel(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		label     string
+		expectErr bool
+	}{
+		{
+			caseName: "Label on the first page",
+			label:    "area/core",
+		},
+		{
+			caseName: "Label on the last page",
+			label:    "kind/bug",
+		},
+		{
+			caseName:  "Unknown label",
+			label:     "kind/unknown",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			var added []string
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/v3/repos/owner/repo/labels", paginate(t, labels("area/core", "area/api", "kind/feature", "kind/bug"), 2, nil))
+			mux.HandleFunc("POST /api/v3/repos/owner/repo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&added))
+				_, _ = fmt.Fprint(w, `[]`)
+			})
+
+			err := CheckAndAddLabel(newTestClient(t, mux), "owner/repo", 1, tc.label)
+			if tc.expectErr {
+				assert.Equal(t, &LabelNotFoundError{
+					Label:  tc.label,
+					Labels: []string{"area/core", "area/api", "kind/feature", "kind/bug"},
+				}, err)
+				assert.Empty(t, added)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tc.label}, added)
+		})
+	}
+}
+
+func TestLabelOptions(t *testing.T) {
+	cases := []struct {
+		caseName string
+		labels   []string
+		prefix   string
+		expect   []string
+	}{
+		{
+			caseName: "Labels with the prefix",
+			labels:   []string{"kind/bug", "area/core", "area/api", "lgtm"},
+			prefix:   "area/",
+			expect:   []string{"api", "core"},
+		},
+		{
+			caseName: "Ignore the label named after the prefix",
+			labels:   []string{"area/", "area/core"},
+			prefix:   "area/",
+			expect:   []string{"core"},
+		},
+		{
+			caseName: "No labels with the prefix",
+			labels:   []string{"lgtm"},
+			prefix:   "kind/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, LabelOptions(tc.labels, tc.prefix))
+		})
+	}
+}
+
+func TestHasLabel(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/issues/1/labels", paginate(t, labels("area/core", "area/api", "lgtm"), 2, nil))
+	ghClient := newTestClient(t, mux)
+
+	err, has := HasLabel(ghClient, "owner/repo", "lgtm", 1)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	err, has = HasLabel(ghClient, "owner/repo", "approved", 1)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestListCheckRuns(t *testing.T) {
+	var runs []*github.CheckRun
+	for i := range 5 {
+		runs = append(runs, &github.CheckRun{ID: github.Ptr(int64(i))})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/commits/sha/check-runs", paginate(t, runs, 2, func(page []*github.CheckRun) any {
+		return github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: page}
+	}))
+
+	checkRuns, err := ListCheckRuns(newTestClient(t, mux), "owner/repo", "sha")
+	assert.NoError(t, err)
+	assert.Equal(t, runs, checkRuns)
+}
+
+func TestSearchIssues(t *testing.T) {
+	var issues []*github.Issue
+	for i := range 5 {
+		issues = append(issues, &github.Issue{Number: github.Ptr(i + 1)})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/search/issues", paginate(t, issues, 2, func(page []*github.Issue) any {
+		return github.IssuesSearchResult{Total: github.Ptr(len(issues)), Issues: page}
+	}))
+
+	found, err := SearchIssues(newTestClient(t, mux), "repo:owner/repo is:open")
+	assert.NoError(t, err)
+	assert.Equal(t, issues, found)
+}
+
+func TestListOpenIssuesByAssignee(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Ptr(1)},
+		{Number: github.Ptr(2), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("pr")}},
+		{Number: github.Ptr(3)},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "alice", r.URL.Query().Get("assignee"))
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		paginate(t, issues, 1, nil)(w, r)
+	})
+
+	found, err := ListOpenIssuesByAssignee(newTestClient(t, mux), "owner/repo", "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []*github.Issue{issues[0], issues[2]}, found)
+}
@@ -19,39 +19,82 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/google/go-github/v72/github"
-	"github.com/gookit/slog"
-	"github.com/gookit/slog/handler"
+	"github.com/hashicorp/go-multierror"
 	"github.com/jinzhu/copier"
 	"golang.org/x/oauth2"
 	oauthGh "golang.org/x/oauth2/github"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/feedback"
+	"github.com/ShyunnY/actbot/internal/logging"
 	"github.com/ShyunnY/actbot/internal/options/dingtalk"
+	"github.com/ShyunnY/actbot/internal/owners"
+	"github.com/ShyunnY/actbot/internal/permission"
 )
 
-// initialize the global logger
-var logger = func() *slog.Logger {
-	return slog.NewWithConfig(func(inner *slog.Logger) {
-		consoleHandler := handler.NewConsoleHandler(slog.AllLevels)
-		inner.ChannelName = "actbot"
-		inner.AddHandler(consoleHandler)
-	})
-}()
+var (
+	// logHandler writes the records of all loggers in the format chosen by the environment,
+	// an invalid format falls back to the console and is reported by Setup.
+	logFormat, logFormatErr = logging.FormatFromEnv(os.Getenv)
+	logHandler              = logging.NewHandler(logFormat, os.Stdout)
+
+	// initialize the global logger
+	logger = logging.New(logHandler)
+)
 
 func Setup() error {
+	// closing the logger ends the group of the last actor in the workflow logs
+	defer func() { _ = logger.Close() }()
+
 	var (
 		ghToken       = os.Getenv("token")
 		ghEvent       = os.Getenv("GITHUB_EVENT_NAME")
 		ghEventPath   = os.Getenv("GITHUB_EVENT_PATH")
 		dingTalkToken = os.Getenv("dingTalkToken")
+		configPath    = os.Getenv("configPath")
+		botLogin      = os.Getenv("botLogin")
+		ghRepository  = os.Getenv("GITHUB_REPOSITORY")
+		ghStepSummary = os.Getenv("GITHUB_STEP_SUMMARY")
+		ghOutput      = os.Getenv("GITHUB_OUTPUT")
 	)
 
+	if logFormatErr != nil {
+		logger.Warnf("failed to parse the log format by err: %v, falling back to the console", logFormatErr)
+	}
+	logging.Mask(logHandler, ghToken, dingTalkToken)
+
 	gitHubClient, err := InitGitHubClient(ghToken)
 	if err != nil {
-		exit("failed to init GitHub client by err: %v", err)
+		return fmt.Errorf("failed to init GitHub client by err: %w", err)
+	}
+	ghClient := actors.NewClient(gitHubClient)
+	botLogin, err = resolveBotLogin(ghClient, botLogin)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the bot login by err: %w", err)
+	}
+	ghClient = ghClient.WithBotLogin(botLogin)
+	logger.Infof("comment as '%s'", botLogin)
+
+	if len(configPath) == 0 {
+		configPath = config.DefaultPath
+	}
+	cfg, err := loadConfig(ghClient, ghRepository, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config by err: %w", err)
+	}
+
+	// OWNERS files are optional, commands keep working with the collaborator roles without them.
+	repoOwners, err := loadOwners(ghClient, ghRepository)
+	if err != nil {
+		logger.Warnf("failed to load OWNERS files by err: %v", err)
 	}
 
 	// The GitHub Actor itself should focus on GitHub-related operations.
@@ -61,56 +104,212 @@ func Setup() error {
 	// This is where all the Options are built to pass on.
 	options := &actors.Options{
 		DingTalkClient: dingtalk.NewDingTalkClient(dingTalkToken, logger),
+		Config:         cfg,
+		Owners:         repoOwners,
+		Permissions:    permission.NewChecker(ghClient.Repositories, ghRepository, repoOwners),
+		Repository:     ghRepository,
+	}
+
+	report, err := dispatch(ghEvent, ghEventPath, ghClient, options)
+	if err != nil {
+		return fmt.Errorf("failed to dispatch event by err: %w", err)
 	}
+	logger.Infof(
+		"dispatched %s event: %d handled, %d denied, %d failed",
+		ghEvent, report.Count(Handled), report.Count(Denied), report.Count(Failed),
+	)
 
-	if err := dispatch(ghEvent, ghEventPath, gitHubClient, options); err != nil {
-		exit("failed to dispatch event by err: %v", err)
+	// the job summary and the step outputs are only available in a workflow run
+	if len(ghStepSummary) != 0 {
+		if err := writeStepSummary(ghStepSummary, report); err != nil {
+			logger.Warnf("failed to write the job summary by err: %v", err)
+		}
+	}
+	if len(ghOutput) != 0 {
+		if err := writeOutputs(ghOutput, report); err != nil {
+			logger.Warnf("failed to write the step outputs by err: %v", err)
+		}
 	}
 
-	return nil
+	return report.Err()
 }
 
-func dispatch(ghEvent, ghEventPath string, ghClient *github.Client, opts *actors.Options) error {
+// dispatch runs every actor registered for the event, a failing actor does not prevent
+// the remaining ones from running. The returned error is only about the event itself,
+// the failures of the actors are in the report.
+func dispatch(ghEvent, ghEventPath string, ghClient *actors.Client, opts *actors.Options) (*Report, error) {
 	if len(ghEvent) == 0 {
-		return errors.New("empty github event")
+		return nil, errors.New("empty github event")
 	}
 	ghEventBytes, err := readGitHubEvent(ghEventPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	switch ghEvent {
-	case string(IssueComment):
-		var (
-			evt          github.IssueCommentEvent
-			genericEvent actors.GenericEvent
-		)
-		if err := json.Unmarshal(ghEventBytes, &evt); err != nil {
-			return fmt.Errorf("unmarshal '%s' github event: %w", IssueComment, err)
+	eventType := GitHubEventType(ghEvent)
+	evt, err := parseGitHubEvent(eventType, ghEventBytes)
+	if err != nil {
+		return nil, err
+	}
+	genericEvent := actors.GenericEvent{
+		Name:  ghEvent,
+		Event: evt,
+	}
+
+	// record the changes made by the actors for the run report
+	journal := &actors.Journal{}
+	ghClient = journal.Record(ghClient)
+
+	report := &Report{Event: ghEvent}
+	report.Sender, report.Number = subject(evt)
+	// the commands denied to the commenter by any actor are replied to at once
+	var denied []string
+	for _, reg := range actorMap[eventType] {
+		// the records of every actor are grouped in the workflow logs
+		logger := logging.WithActor(logHandler, reg.name)
+		actor := reg.fn(ghClient.WithContext(logging.NewContext(context.Background(), logger)), logger, opts)
+		journal.SetActor(actor.Name())
+		if !opts.Config.ActorEnabled(reg.name, ghEvent) {
+			logger.Infof("actor %s is disabled for %s event by config", actor.Name(), eventType)
+			report.add(actor, Disabled, nil)
+			continue
 		}
-		genericEvent.Event = evt
 
-		for _, fn := range actorMap[IssueComment] {
-			event, err := copyEvent(&genericEvent)
-			if err != nil {
-				return err
-			}
+		event, err := copyEvent(&genericEvent)
+		if err != nil {
+			logger.Errorf("actor %s copy event by err: %s", actor.Name(), err)
+			report.add(actor, Failed, err)
+			continue
+		}
+		if !actor.Capture(*event) {
+			report.add(actor, Skipped, nil)
+			continue
+		}
 
-			actor := fn(ghClient, logger, opts)
-			if actor.Capture(*event) {
-				if err = actor.Handler(); err != nil {
-					exit("actor %s handle by err: %s", actor.Name(), err)
+		deniedCommands, err := authorize(actor, *event, opts)
+		if err != nil {
+			logger.Errorf("actor %s authorize by err: %s", actor.Name(), err)
+			report.add(actor, Failed, err)
+			continue
+		}
+		if len(deniedCommands) != 0 {
+			logger.Infof("actor %s is not allowed to handle %s event for the commenter", actor.Name(), eventType)
+			for _, command := range deniedCommands {
+				if !slices.Contains(denied, command) {
+					denied = append(denied, command)
 				}
-
-				logger.Infof("actor %s successfully handle %s event", actor.Name(), IssueComment)
 			}
+			report.add(actor, Denied, nil)
+			continue
+		}
+
+		if err = actor.Handler(); err != nil {
+			logger.Errorf("actor %s handle by err: %s", actor.Name(), err)
+			report.add(actor, Failed, err)
+			continue
+		}
+
+		logger.Infof("actor %s successfully handle %s event", actor.Name(), eventType)
+		report.add(actor, Handled, nil)
+	}
+
+	journal.SetActor("")
+	if len(denied) != 0 {
+		if err := deny(ghClient, genericEvent, denied); err != nil {
+			logger.Errorf("failed to reply the denied commands to the commenter by err: %s", err)
+		}
+	}
+	if failures := report.Failures(); len(failures) != 0 {
+		if err := feedback.Report(ghClient, genericEvent, failures); err != nil {
+			logger.Errorf("failed to report the failures to the commenter by err: %s", err)
+		}
+	}
+	report.Actions = journal.Actions()
+
+	return report, nil
+}
+
+// authorize checks that the commenter has the role required by every command captured
+// by the actor, and returns the commands the commenter is not allowed to run.
+func authorize(actor actors.Actor, event actors.GenericEvent, opts *actors.Options) ([]string, error) {
+	commandActor, ok := actor.(actors.CommandActor)
+	if !ok {
+		return nil, nil
+	}
+	commentEvent, ok := event.Event.(github.IssueCommentEvent)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		login  = commentEvent.GetComment().GetUser().GetLogin()
+		author = commentEvent.GetIssue().GetUser().GetLogin()
+		denied []string
+	)
+	for _, command := range commandActor.Commands() {
+		if opts.Config.AuthorCommand(command.Name) && strings.EqualFold(login, author) {
+			continue
 		}
 
+		role := opts.Config.CommandRole(command.Name)
+		has, err := opts.Permissions.HasRole(login, role)
+		if err != nil {
+			return nil, err
+		}
+
+		deniedCommand := fmt.Sprintf("`/%s` (requires the `%s` role)", command.Name, role)
+		if !has && !slices.Contains(denied, deniedCommand) {
+			denied = append(denied, deniedCommand)
+		}
+	}
+
+	return denied, nil
+}
+
+// deny politely replies to the commenter with the commands it is not allowed to run.
+func deny(ghClient *actors.Client, event actors.GenericEvent, denied []string) error {
+	commentEvent, ok := event.Event.(github.IssueCommentEvent)
+	if !ok {
+		return nil
+	}
+
+	return actors.AddComment(
+		ghClient,
+		fmt.Sprintf(
+			"@%s thanks for your help! Only the users with the required role in this repository can run %s, please ask a maintainer to do it for you.",
+			commentEvent.GetComment().GetUser().GetLogin(), strings.Join(denied, ", "),
+		),
+		commentEvent.GetRepo().GetFullName(),
+		commentEvent.GetIssue().GetNumber(),
+	)
+}
+
+// parseGitHubEvent unmarshal the event payload into the typed go-github event
+// that the actors registered for the event type expect to receive.
+func parseGitHubEvent(eventType GitHubEventType, payload []byte) (any, error) {
+	switch eventType {
+	case IssueComment:
+		return unmarshalGitHubEvent[github.IssueCommentEvent](eventType, payload)
+	case Issues:
+		return unmarshalGitHubEvent[github.IssuesEvent](eventType, payload)
+	case PullRequest, PullRequestTarget:
+		// 'pull_request_target' shares the payload of 'pull_request',
+		// it only differs in the context in which the workflow runs.
+		return unmarshalGitHubEvent[github.PullRequestEvent](eventType, payload)
+	case Schedule:
+		return unmarshalGitHubEvent[actors.ScheduleEvent](eventType, payload)
 	default:
-		return errors.New("unsupported github event")
+		return nil, errors.New("unsupported github event")
 	}
+}
 
-	return nil
+func unmarshalGitHubEvent[T any](eventType GitHubEventType, payload []byte) (any, error) {
+	var evt T
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return nil, fmt.Errorf("unmarshal '%s' github event: %w", eventType, err)
+	}
+
+	return evt, nil
 }
 
 func readGitHubEvent(ghEventPath string) ([]byte, error) {
@@ -131,6 +330,126 @@ func readGitHubEvent(ghEventPath string) ([]byte, error) {
 	return eventBytes, nil
 }
 
+// resolveBotLogin returns the login of the user behind the token, the comments of the bot are recognized by it.
+// The bots of the GitHub Apps, such as the one of the GITHUB_TOKEN, cannot read themselves, so the configured
+// login is required with the token of a GitHub App, DefaultBotLogin is used for the GITHUB_TOKEN.
+func resolveBotLogin(ghClient *actors.Client, login string) (string, error) {
+	if len(login) != 0 {
+		return login, nil
+	}
+
+	user, resp, err := ghClient.Users.Get(ghClient.Context(), "")
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusForbidden:
+		return actors.DefaultBotLogin, nil
+	case err != nil:
+		return "", err
+	}
+
+	return user.GetLogin(), nil
+}
+
+// loadConfig loads the repository config file of the default branch from the GitHub API,
+// if the repository does not have a config file, the default config is returned.
+// The config grants roles to the commands, so it is never read from the workspace:
+// a 'pull_request_target' workflow may check out the pull request, whose author would then
+// configure the bot for themselves.
+func loadConfig(ghClient *actors.Client, ghRepository, path string) (*config.Config, error) {
+	var content []byte
+	if len(ghRepository) != 0 {
+		var err error
+		content, err = actors.GetFileContent(ghClient, ghRepository, path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file '%s': %w", path, err)
+		}
+	}
+	if content == nil {
+		logger.Infof("config file '%s' not found, use the default config", path)
+		return config.Default(), nil
+	}
+
+	cfg, err := config.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateActorEvents(cfg); err != nil {
+		return nil, err
+	}
+	logger.Infof("loaded config file '%s'", path)
+
+	return cfg, nil
+}
+
+// loadOwners loads the OWNERS files of the default branch of the repository from the GitHub API.
+// The OWNERS files grant roles, so they are never read from the workspace: a 'pull_request_target'
+// workflow may check out the pull request, whose author would then grant roles to themselves.
+func loadOwners(ghClient *actors.Client, ghRepository string) (*owners.Owners, error) {
+	if len(ghRepository) == 0 {
+		return nil, nil
+	}
+
+	paths, err := actors.ListFilesByName(ghClient, ghRepository, owners.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		if vendored(path) {
+			continue
+		}
+		content, err := actors.GetFileContent(ghClient, ghRepository, path)
+		if err != nil {
+			return nil, fmt.Errorf("read '%s': %w", path, err)
+		}
+		files[path] = content
+	}
+	aliases, err := actors.GetFileContent(ghClient, ghRepository, owners.AliasesFileName)
+	if err != nil {
+		return nil, fmt.Errorf("read '%s': %w", owners.AliasesFileName, err)
+	}
+	logger.Infof("loaded %d OWNERS files", len(files))
+
+	return owners.New(files, aliases)
+}
+
+// vendored reports whether the path is in a directory of third-party code,
+// the OWNERS files shipped with the dependencies do not own the repository.
+func vendored(path string) bool {
+	for _, dir := range strings.Split(path, "/") {
+		if dir == "vendor" || dir == "node_modules" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateActorEvents checks that actors are only configured for the events they are registered for.
+func validateActorEvents(cfg *config.Config) error {
+	registered := make(map[string][]string)
+	for eventType, registrations := range actorMap {
+		for _, reg := range registrations {
+			registered[reg.name] = append(registered[reg.name], string(eventType))
+		}
+	}
+
+	var errs *multierror.Error
+	for _, name := range slices.Sorted(maps.Keys(registered)) {
+		actorConfig, _ := cfg.Actor(name)
+		for _, event := range actorConfig.Events {
+			if !slices.Contains(registered[name], event) {
+				errs = multierror.Append(errs, fmt.Errorf("actors.%s.events: actor does not handle '%s' event", name, event))
+			}
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
 func InitGitHubClient(ghToken string) (*github.Client, error) {
 	if len(ghToken) == 0 {
 		return nil, errors.New("empty github token")
@@ -143,6 +462,8 @@ func InitGitHubClient(ghToken string) (*github.Client, error) {
 		context.Background(),
 		&oauth2.Token{AccessToken: ghToken},
 	)
+	// retry the calls rejected by the rate limits of GitHub or failing transiently
+	oClient.Transport = newRetryTransport(oClient.Transport)
 	ghClient := github.NewClient(oClient)
 
 	return ghClient, nil
@@ -158,11 +479,3 @@ func copyEvent(src *actors.GenericEvent) (*actors.GenericEvent, error) {
 
 	return &dst, nil
 }
-
-func exit(format string, err ...any) {
-	// avoid losing the call stack information
-	logger.CallerSkip += 1
-
-	logger.Errorf(format, err...)
-	os.Exit(1)
-}
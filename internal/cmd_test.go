@@ -15,9 +15,22 @@
 package internal
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/google/go-github/v72/github"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+	"github.com/ShyunnY/actbot/internal/config"
+	"github.com/ShyunnY/actbot/internal/options/dingtalk"
+	"github.com/ShyunnY/actbot/internal/permission"
 )
 
 func TestInitGitHubClient(t *testing.T) {
@@ -51,3 +64,546 @@ func TestInitGitHubClient(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGitHubEvent(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		eventType GitHubEventType
+		payload   string
+		expect    any
+		expectErr bool
+	}{
+		{
+			caseName:  "Parse issue_comment event",
+			eventType: IssueComment,
+			payload:   `{"action":"created","comment":{"body":"/retest"}}`,
+			expect: github.IssueCommentEvent{
+				Action:  github.Ptr("created"),
+				Comment: &github.IssueComment{Body: github.Ptr("/retest")},
+			},
+		},
+		{
+			caseName:  "Parse pull_request event",
+			eventType: PullRequest,
+			payload:   `{"action":"synchronize","number":1}`,
+			expect: github.PullRequestEvent{
+				Action: github.Ptr("synchronize"),
+				Number: github.Ptr(1),
+			},
+		},
+		{
+			caseName:  "Parse pull_request_target event",
+			eventType: PullRequestTarget,
+			payload:   `{"action":"opened","number":2}`,
+			expect: github.PullRequestEvent{
+				Action: github.Ptr("opened"),
+				Number: github.Ptr(2),
+			},
+		},
+		{
+			caseName:  "Parse issues event",
+			eventType: Issues,
+			payload:   `{"action":"opened","issue":{"number":3}}`,
+			expect: github.IssuesEvent{
+				Action: github.Ptr("opened"),
+				Issue:  &github.Issue{Number: github.Ptr(3)},
+			},
+		},
+		{
+			caseName:  "Parse schedule event",
+			eventType: Schedule,
+			payload:   `{"schedule":"0 0 * * *","workflow":".github/workflows/actbot.yml"}`,
+			expect: actors.ScheduleEvent{
+				Schedule: "0 0 * * *",
+				Workflow: ".github/workflows/actbot.yml",
+			},
+		},
+		{
+			caseName:  "Malformed event payload",
+			eventType: PullRequest,
+			payload:   `{"action":`,
+			expectErr: true,
+		},
+		{
+			caseName:  "Unsupported event",
+			eventType: "fork",
+			payload:   `{}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			evt, err := parseGitHubEvent(tc.eventType, []byte(tc.payload))
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, evt)
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		ghEvent   string
+		payload   string
+		expectErr bool
+	}{
+		{
+			caseName: "Dispatch pull_request event",
+			ghEvent:  string(PullRequest),
+			payload:  `{"action":"edited","number":1}`,
+		},
+		{
+			caseName: "Dispatch pull_request_target event",
+			ghEvent:  string(PullRequestTarget),
+			payload:  `{"action":"labeled","number":1}`,
+		},
+		{
+			caseName:  "Dispatch empty event",
+			ghEvent:   "",
+			payload:   `{}`,
+			expectErr: true,
+		},
+		{
+			caseName:  "Dispatch unsupported event",
+			ghEvent:   "fork",
+			payload:   `{}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			eventPath := filepath.Join(t.TempDir(), "event.json")
+			err := os.WriteFile(eventPath, []byte(tc.payload), 0o600)
+			assert.NoError(t, err)
+
+			_, err = dispatch(tc.ghEvent, eventPath, actors.NewClient(github.NewClient(nil)), &actors.Options{Config: config.Default()})
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestDispatchFixtures(t *testing.T) {
+	cases := []struct {
+		caseName     string
+		ghEvent      string
+		fixture      string
+		seed         func(gh *fake.GitHub)
+		expect       func(t *testing.T, gh *fake.GitHub)
+		expectFailed []string
+	}{
+		{
+			caseName: "Assign an issue to the commenter",
+			ghEvent:  string(IssueComment),
+			fixture:  "issue_comment_assign.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Collaborators["alice"] = "read"
+				gh.Issues[1] = &github.Issue{
+					Number: github.Ptr(1),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr(actors.HelpWantedLabel)}},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				assert.Equal(t, []string{"alice"}, gh.AssigneeLogins(1))
+				assert.Empty(t, gh.LabelNames(1))
+				assert.Equal(t, []string{actors.CommendReaction}, gh.Reactions[1001])
+			},
+		},
+		{
+			caseName: "Label an issue by a triager",
+			ghEvent:  string(IssueComment),
+			fixture:  "issue_comment_labels.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Collaborators["bob"] = "triage"
+				gh.Labels = []*github.Label{{Name: github.Ptr("area/core")}, {Name: github.Ptr("kind/bug")}}
+				gh.Issues[1] = &github.Issue{
+					Number: github.Ptr(1),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr(actors.NeedsTriageLabel)}},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				assert.Equal(t, []string{"area/core", "kind/bug"}, gh.LabelNames(1))
+				assert.Empty(t, gh.CommentBodies(1))
+			},
+		},
+		{
+			caseName: "Deny the labels of a reader",
+			ghEvent:  string(IssueComment),
+			fixture:  "issue_comment_labels.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Collaborators["bob"] = "read"
+				gh.Labels = []*github.Label{{Name: github.Ptr("area/core")}, {Name: github.Ptr("kind/bug")}}
+				gh.Issues[1] = &github.Issue{
+					Number: github.Ptr(1),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr(actors.NeedsTriageLabel)}},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				assert.Equal(t, []string{actors.NeedsTriageLabel}, gh.LabelNames(1))
+				comments := gh.CommentBodies(1)
+				// the commands denied by every actor are replied to in a single comment
+				if assert.Len(t, comments, 1) {
+					assert.Contains(t, comments[0], "`/area` (requires the `triage` role), `/kind` (requires the `triage` role)")
+				}
+			},
+		},
+		{
+			caseName: "Report an unknown label to the commenter",
+			ghEvent:  string(IssueComment),
+			fixture:  "issue_comment_unknown_label.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Collaborators["bob"] = "triage"
+				gh.Labels = []*github.Label{{Name: github.Ptr("area/core")}, {Name: github.Ptr("area/docs")}, {Name: github.Ptr("kind/bug")}}
+				gh.Issues[1] = &github.Issue{
+					Number: github.Ptr(1),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr(actors.NeedsTriageLabel)}},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				// the kind actor still runs after the area actor failed
+				assert.Equal(t, []string{"kind/bug"}, gh.LabelNames(1))
+				assert.Equal(t, []string{
+					"@bob sorry, your comment could not be fully handled:\n" +
+						"\n- `/area foo`: the `area/foo` label does not exist. The valid options are `core`, `docs`.",
+				}, gh.CommentBodies(1))
+				assert.Equal(t, []string{actors.ConfusedReaction}, gh.Reactions[1004])
+			},
+			expectFailed: []string{"AreaLabelerActor"},
+		},
+		{
+			caseName: "Rerun the failed checks of a pull request",
+			ghEvent:  string(IssueComment),
+			fixture:  "issue_comment_retest.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Issues[2] = &github.Issue{Number: github.Ptr(2), State: github.Ptr("open")}
+				gh.PullRequests[2] = &github.PullRequest{
+					Number: github.Ptr(2),
+					State:  github.Ptr("open"),
+					Head:   &github.PullRequestBranch{SHA: github.Ptr("abc")},
+				}
+				gh.CheckRuns["abc"] = []*github.CheckRun{
+					{ID: github.Ptr(int64(11)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(12)), Name: github.Ptr("lint"), Conclusion: github.Ptr("success")},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				reruns := gh.Called("Actions.RerunJobByID")
+				if assert.Len(t, reruns, 1) {
+					assert.Equal(t, []any{int64(11)}, reruns[0].Args)
+				}
+				assert.Equal(t, []string{actors.RocketReaction}, gh.Reactions[1003])
+				assert.Empty(t, gh.CommentBodies(2))
+			},
+		},
+		{
+			caseName: "Triage an opened issue",
+			ghEvent:  string(Issues),
+			fixture:  "issues_opened.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Issues[3] = &github.Issue{Number: github.Ptr(3), State: github.Ptr("open")}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				assert.Equal(t, []string{actors.NeedsTriageLabel}, gh.LabelNames(3))
+			},
+		},
+		{
+			caseName: "Reset the lgtm label of a pushed pull request",
+			ghEvent:  string(PullRequestTarget),
+			fixture:  "pull_request_target_synchronize.json",
+			seed: func(gh *fake.GitHub) {
+				gh.Issues[2] = &github.Issue{
+					Number: github.Ptr(2),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr(config.Default().Actors.LGTM.Label)}},
+				}
+			},
+			expect: func(t *testing.T, gh *fake.GitHub) {
+				assert.Empty(t, gh.LabelNames(2))
+				assert.Len(t, gh.CommentBodies(2), 1)
+				statuses := gh.Statuses["2222222222222222222222222222222222222222"]
+				if assert.Len(t, statuses, 1) {
+					assert.Equal(t, "success", statuses[0].GetState())
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			tc.seed(gh)
+			server := gh.NewServer()
+			defer server.Close()
+
+			ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+			assert.NoError(t, err)
+
+			report, err := dispatch(tc.ghEvent, filepath.Join("testdata", "events", tc.fixture), actors.NewClient(ghClient), &actors.Options{
+				Config:         config.Default(),
+				Permissions:    permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+				Repository:     "owner/repo",
+				DingTalkClient: dingtalk.NewDingTalkClient("", logger),
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ghEvent, report.Event)
+			var failed []string
+			for _, failure := range report.Failures() {
+				failed = append(failed, failure.Actor)
+			}
+			assert.Equal(t, tc.expectFailed, failed)
+			assert.Equal(t, len(tc.expectFailed) != 0, report.Err() != nil)
+			tc.expect(t, gh)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		content   *string
+		expect    func() *config.Config
+		expectErr bool
+	}{
+		{
+			caseName: "Load the config file from the default branch",
+			content:  github.Ptr("actors:\n  area:\n    prefix: component/\n"),
+			expect: func() *config.Config {
+				cfg := config.Default()
+				cfg.Actors.Area.Prefix = "component/"
+				return cfg
+			},
+		},
+		{
+			caseName: "Use the default config without config file",
+			content:  nil,
+			expect:   config.Default,
+		},
+		{
+			caseName:  "Reject actor events the actor is not registered for",
+			content:   github.Ptr("actors:\n  triage:\n    events: [issue_comment]\n"),
+			expectErr: true,
+		},
+		{
+			caseName:  "Reject invalid config file",
+			content:   github.Ptr("actors:\n  unknown: {}\n"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			if tc.content != nil {
+				gh.Contents[config.DefaultPath] = []byte(*tc.content)
+			}
+
+			cfg, err := loadConfig(gh.Client(), "owner/repo", config.DefaultPath)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect(), cfg)
+		})
+	}
+}
+
+func TestNewHelpActor(t *testing.T) {
+	gh := fake.New()
+	gh.Collaborators["bob"] = "triage"
+	gh.Issues[1] = &github.Issue{Number: github.Ptr(1), State: github.Ptr("open")}
+
+	cfg := config.Default()
+	cfg.Actors.Kind.Enabled = github.Ptr(false)
+	ghClient := gh.Client()
+	helpActor := newHelpActor(ghClient, logger, &actors.Options{
+		Config:      cfg,
+		Permissions: permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+	})
+	captured := helpActor.Capture(actors.GenericEvent{
+		Event: github.IssueCommentEvent{
+			Comment: &github.IssueComment{Body: github.Ptr("/help"), User: &github.User{Login: github.Ptr("bob")}},
+			Issue:   gh.Issues[1],
+			Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+		},
+	})
+	assert.True(t, captured)
+	assert.NoError(t, helpActor.Handler())
+
+	comments := gh.CommentBodies(1)
+	if assert.Len(t, comments, 1) {
+		// commands of the enabled actors handling issues
+		assert.Contains(t, comments[0], "| `/assign` |")
+		assert.Contains(t, comments[0], "| `/area <area>...` |")
+		assert.Contains(t, comments[0], "| `/close [not-planned]` |")
+		assert.Contains(t, comments[0], "| `/help` |")
+		// disabled actors and pull request commands are not listed
+		assert.NotContains(t, comments[0], "/kind")
+		assert.NotContains(t, comments[0], "/retest")
+	}
+}
+
+type commandActor struct {
+	commands []actors.Command
+}
+
+func (a *commandActor) Handler() error { return nil }
+
+func (a *commandActor) Capture(actors.GenericEvent) bool { return true }
+
+func (a *commandActor) Name() string { return "CommandActor" }
+
+func (a *commandActor) Commands() []actors.Command { return a.commands }
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		caseName string
+		role     string
+		commands []actors.Command
+		expect   bool
+	}{
+		{
+			caseName: "Commenter has the required role",
+			role:     "triage",
+			commands: []actors.Command{{Name: "area", Args: []string{"core"}}},
+			expect:   true,
+		},
+		{
+			caseName: "Commenter does not have the required role",
+			role:     "read",
+			commands: []actors.Command{{Name: "area", Args: []string{"core"}}, {Name: "unarea", Args: []string{"docs"}}},
+			expect:   false,
+		},
+		{
+			caseName: "Author can run author commands",
+			role:     "read",
+			commands: []actors.Command{{Name: "close"}},
+			expect:   true,
+		},
+		{
+			caseName: "Author cannot run other commands",
+			role:     "read",
+			commands: []actors.Command{{Name: "close"}, {Name: "area", Args: []string{"core"}}},
+			expect:   false,
+		},
+		{
+			caseName: "Commands without required role",
+			role:     "none",
+			commands: []actors.Command{{Name: "assign"}},
+			expect:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/v3/repos/owner/repo/collaborators/alice/permission", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"permission":"read","role_name":%q}`, tc.role)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+			assert.NoError(t, err)
+
+			denied, err := authorize(
+				&commandActor{commands: tc.commands},
+				actors.GenericEvent{
+					Event: github.IssueCommentEvent{
+						Comment: &github.IssueComment{User: &github.User{Login: github.Ptr("alice")}},
+						Issue:   &github.Issue{Number: github.Ptr(1), User: &github.User{Login: github.Ptr("alice")}},
+						Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+					},
+				},
+				&actors.Options{
+					Config:      config.Default(),
+					Permissions: permission.NewChecker(ghClient.Repositories, "owner/repo", nil),
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, len(denied) == 0)
+			if !tc.expect {
+				assert.Contains(t, denied, "`/area` (requires the `triage` role)")
+			}
+		})
+	}
+}
+
+func TestResolveBotLogin(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		login     string
+		tokenUser string
+		err       error
+		expect    string
+		expectErr bool
+	}{
+		{
+			caseName:  "Use the configured login",
+			login:     "actbot[bot]",
+			tokenUser: "alice",
+			expect:    "actbot[bot]",
+		},
+		{
+			caseName:  "Use the owner of a personal access token",
+			tokenUser: "alice",
+			expect:    "alice",
+		},
+		{
+			caseName:  "Use the bot of the GITHUB_TOKEN",
+			tokenUser: actors.DefaultBotLogin,
+			expect:    actors.DefaultBotLogin,
+		},
+		{
+			caseName:  "Fail to read the user",
+			tokenUser: "alice",
+			err:       errors.New("boom"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Login = tc.tokenUser
+			if tc.err != nil {
+				gh.Errors["Users.Get"] = tc.err
+			}
+
+			login, err := resolveBotLogin(gh.Client(), tc.login)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, login)
+		})
+	}
+}
+
+func TestLoadOwners(t *testing.T) {
+	gh := fake.New()
+	gh.Contents = map[string][]byte{
+		"OWNERS":                        []byte("approvers: [maintainers]\n"),
+		"OWNERS_ALIASES":                []byte("aliases:\n  maintainers: [alice]\n"),
+		"pkg/api/OWNERS":                []byte("approvers: [bob]\n"),
+		"vendor/example.com/lib/OWNERS": []byte("approvers: [mallory]\n"),
+		"web/node_modules/pkg/OWNERS":   []byte("approvers: [mallory]\n"),
+		"pkg/api/handler.go":            []byte("package api\n"),
+	}
+
+	repoOwners, err := loadOwners(gh.Client(), "owner/repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob", "alice"}, repoOwners.Approvers("pkg/api/handler.go"))
+	assert.Equal(t, []string{"alice"}, repoOwners.Approvers("vendor/example.com/lib/lib.go"))
+	assert.Equal(t, []string{"alice"}, repoOwners.Approvers("web/node_modules/pkg/index.js"))
+}
@@ -0,0 +1,432 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ShyunnY/actbot/internal/permission"
+)
+
+// DefaultPath is the path of the actbot config file relative to the repository root.
+const DefaultPath = ".github/actbot.yaml"
+
+// The keys of the actors in the config file.
+const (
+	AssignActor    = "assign"
+	RetestActor    = "retest"
+	SyncActor      = "sync"
+	AreaActor      = "area"
+	KindActor      = "kind"
+	TriageActor    = "triage"
+	LGTMActor      = "lgtm"
+	CCActor        = "cc"
+	ApproveActor   = "approve"
+	HoldActor      = "hold"
+	StateActor     = "state"
+	ClaimActor     = "claim"
+	LifecycleActor = "lifecycle"
+	HelpActor      = "help"
+)
+
+// Config is the repository level configuration of actbot,
+// all the fields that are not set in the config file keep their default values.
+type Config struct {
+	Actors      Actors            `yaml:"actors"`
+	Permissions PermissionsConfig `yaml:"permissions"`
+}
+
+// Actors holds the configuration of every actor, keyed by the actor name.
+type Actors struct {
+	Assign    AssignConfig    `yaml:"assign"`
+	Retest    RetestConfig    `yaml:"retest"`
+	Sync      SyncConfig      `yaml:"sync"`
+	Area      LabelerConfig   `yaml:"area"`
+	Kind      LabelerConfig   `yaml:"kind"`
+	Triage    TriageConfig    `yaml:"triage"`
+	LGTM      LGTMConfig      `yaml:"lgtm"`
+	CC        ActorConfig     `yaml:"cc"`
+	Approve   ApproveConfig   `yaml:"approve"`
+	Hold      HoldConfig      `yaml:"hold"`
+	State     ActorConfig     `yaml:"state"`
+	Claim     ClaimConfig     `yaml:"claim"`
+	Lifecycle LifecycleConfig `yaml:"lifecycle"`
+	Help      ActorConfig     `yaml:"help"`
+}
+
+// ActorConfig is the configuration shared by all actors.
+type ActorConfig struct {
+	// Enabled whether the actor is enabled, actors are enabled by default.
+	Enabled *bool `yaml:"enabled"`
+
+	// Events restricts the GitHub events handled by the actor,
+	// by default the actor handles all the events it is registered for.
+	Events []string `yaml:"events"`
+}
+
+// AssignConfig configures the '/[un]assign' actor.
+type AssignConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// AlreadyAssignedMessage is replied when the commenter is already assigned to the issue.
+	AlreadyAssignedMessage string `yaml:"alreadyAssignedMessage"`
+
+	// NotAssignedMessage is replied when the commenter unassigns an issue not assigned to them.
+	NotAssignedMessage string `yaml:"notAssignedMessage"`
+
+	// OthersRole is the role required to '/[un]assign @user' other users,
+	// anyone can still assign the issue to themselves.
+	OthersRole permission.Role `yaml:"othersRole"`
+
+	// MaxAssignees is the number of assignees above which users can no longer
+	// claim the issue with '/assign', 1 makes the assignment exclusive and 0 disables the limit.
+	MaxAssignees int `yaml:"maxAssignees"`
+
+	// MaxOpenIssues is the number of open issues of the repository a user can be
+	// assigned to before being unable to claim another one, 0 disables the quota.
+	MaxOpenIssues int `yaml:"maxOpenIssues"`
+
+	// OverrideRole is the role of the users that are not limited by the assignment policy,
+	// both when claiming an issue and when assigning other users.
+	OverrideRole permission.Role `yaml:"overrideRole"`
+}
+
+// RetestConfig configures the '/retest' actor.
+type RetestConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// NoFailedChecksMessage is replied when there are no failed checks to rerun.
+	NoFailedChecksMessage string `yaml:"noFailedChecksMessage"`
+}
+
+// SyncConfig configures the '/sync' actor.
+type SyncConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Label marks the issues that have been synced to the DingTalk group.
+	Label string `yaml:"label"`
+}
+
+// LabelerConfig configures the '/[un]area' and '/[un]kind' actors.
+type LabelerConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Prefix is prepended to the command arguments to build the label names.
+	Prefix string `yaml:"prefix"`
+}
+
+// TriageConfig configures the actor labeling newly opened issues.
+type TriageConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Labels are applied in addition to the 'needs-triage' label.
+	Labels []string `yaml:"labels"`
+}
+
+// LGTMConfig configures the '/lgtm' actor.
+type LGTMConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Label marks the pull requests that have been reviewed.
+	Label string `yaml:"label"`
+
+	// Review additionally submits an approving review on behalf of the bot.
+	Review bool `yaml:"review"`
+}
+
+// ApproveConfig configures the '/approve' actor.
+type ApproveConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Label marks the pull requests whose changed files have all been approved by their OWNERS.
+	Label string `yaml:"label"`
+}
+
+// HoldConfig configures the '/[un]hold' actor.
+type HoldConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// Label marks the pull requests that are on hold.
+	Label string `yaml:"label"`
+
+	// StatusContext is the name of the commit status published on the head commit,
+	// make it a required status check for the hold to block merging.
+	StatusContext string `yaml:"statusContext"`
+}
+
+// ClaimConfig configures the scheduled actor expiring the '/assign' claims of inactive assignees.
+type ClaimConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// InactiveDays is the number of days without activity of the assignee
+	// after which the assignee is asked whether they are still working on the issue.
+	InactiveDays int `yaml:"inactiveDays"`
+
+	// GraceDays is the number of days the assignee has to answer before being unassigned.
+	GraceDays int `yaml:"graceDays"`
+}
+
+// LifecycleConfig configures the scheduled actor marking inactive issues and pull requests
+// as 'lifecycle/stale', then 'lifecycle/rotten' before closing them.
+type LifecycleConfig struct {
+	ActorConfig `yaml:",inline"`
+
+	// StaleDays is the number of days without activity before being marked as stale.
+	StaleDays int `yaml:"staleDays"`
+
+	// RottenDays is the number of days without activity of stale ones before being marked as rotten.
+	RottenDays int `yaml:"rottenDays"`
+
+	// CloseDays is the number of days without activity of rotten ones before being closed.
+	CloseDays int `yaml:"closeDays"`
+
+	// ExemptLabels are the labels exempting issues and pull requests from the lifecycle,
+	// in addition to 'lifecycle/frozen'.
+	ExemptLabels []string `yaml:"exemptLabels"`
+}
+
+// PermissionsConfig configures who is allowed to run the commands.
+type PermissionsConfig struct {
+	// Commands maps the command names, such as "area", to the minimum role
+	// in the repository required to run them, the commands that are not listed
+	// can be run by anyone.
+	Commands map[string]permission.Role `yaml:"commands"`
+
+	// AuthorCommands are the commands that the author of the issue or
+	// pull request can always run, whatever their role is.
+	AuthorCommands []string `yaml:"authorCommands"`
+}
+
+// Default returns the configuration used when the repository has no config file.
+func Default() *Config {
+	return &Config{
+		Actors: Actors{
+			Assign: AssignConfig{
+				AlreadyAssignedMessage: "The issue has been assigned to you. Please do not attempt to assign it",
+				NotAssignedMessage:     "This issue is no assigned to you. Please do not try to unassign it again",
+				OthersRole:             permission.RoleTriage,
+				MaxAssignees:           1,
+				OverrideRole:           permission.RoleTriage,
+			},
+			Retest: RetestConfig{
+				NoFailedChecksMessage: "The current checks run has all been run successfully and there is no need to rerun it again",
+			},
+			Sync: SyncConfig{
+				Label: "sync",
+			},
+			Area: LabelerConfig{
+				Prefix: "area/",
+			},
+			Kind: LabelerConfig{
+				Prefix: "kind/",
+			},
+			LGTM: LGTMConfig{
+				Label: "lgtm",
+			},
+			Approve: ApproveConfig{
+				Label: "approved",
+			},
+			Hold: HoldConfig{
+				Label:         "do-not-merge/hold",
+				StatusContext: "actbot/hold",
+			},
+			Claim: ClaimConfig{
+				InactiveDays: 14,
+				GraceDays:    7,
+			},
+			Lifecycle: LifecycleConfig{
+				StaleDays:  90,
+				RottenDays: 30,
+				CloseDays:  30,
+			},
+		},
+		Permissions: PermissionsConfig{
+			Commands: map[string]permission.Role{
+				"area":             permission.RoleTriage,
+				"unarea":           permission.RoleTriage,
+				"kind":             permission.RoleTriage,
+				"unkind":           permission.RoleTriage,
+				"sync":             permission.RoleTriage,
+				"lgtm":             permission.RoleTriage,
+				"hold":             permission.RoleTriage,
+				"unhold":           permission.RoleTriage,
+				"close":            permission.RoleTriage,
+				"reopen":           permission.RoleTriage,
+				"lifecycle":        permission.RoleTriage,
+				"remove-lifecycle": permission.RoleTriage,
+			},
+			AuthorCommands: []string{"close", "reopen"},
+		},
+	}
+}
+
+// Parse parses the content of a config file on top of the default configuration and validates it.
+// Unknown fields are rejected so that typos do not silently fall back to the defaults.
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the configured values are usable by the actors.
+func (c *Config) Validate() error {
+	var errs *multierror.Error
+	for _, name := range slices.Sorted(maps.Keys(c.actors())) {
+		for _, event := range c.actors()[name].Events {
+			if len(strings.TrimSpace(event)) == 0 {
+				errs = multierror.Append(errs, fmt.Errorf("actors.%s.events: empty event name", name))
+			}
+		}
+	}
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{field: "actors.assign.alreadyAssignedMessage", value: c.Actors.Assign.AlreadyAssignedMessage},
+		{field: "actors.assign.notAssignedMessage", value: c.Actors.Assign.NotAssignedMessage},
+		{field: "actors.retest.noFailedChecksMessage", value: c.Actors.Retest.NoFailedChecksMessage},
+		{field: "actors.sync.label", value: c.Actors.Sync.Label},
+		{field: "actors.area.prefix", value: c.Actors.Area.Prefix},
+		{field: "actors.kind.prefix", value: c.Actors.Kind.Prefix},
+		{field: "actors.lgtm.label", value: c.Actors.LGTM.Label},
+		{field: "actors.approve.label", value: c.Actors.Approve.Label},
+		{field: "actors.hold.label", value: c.Actors.Hold.Label},
+		{field: "actors.hold.statusContext", value: c.Actors.Hold.StatusContext},
+	}
+	for _, r := range required {
+		if len(strings.TrimSpace(r.value)) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%s: must not be empty", r.field))
+		}
+	}
+
+	limits := []struct {
+		field string
+		value int
+	}{
+		{field: "actors.assign.maxAssignees", value: c.Actors.Assign.MaxAssignees},
+		{field: "actors.assign.maxOpenIssues", value: c.Actors.Assign.MaxOpenIssues},
+	}
+	for _, l := range limits {
+		if l.value < 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%s: must not be negative", l.field))
+		}
+	}
+
+	durations := []struct {
+		field string
+		value int
+	}{
+		{field: "actors.claim.inactiveDays", value: c.Actors.Claim.InactiveDays},
+		{field: "actors.claim.graceDays", value: c.Actors.Claim.GraceDays},
+		{field: "actors.lifecycle.staleDays", value: c.Actors.Lifecycle.StaleDays},
+		{field: "actors.lifecycle.rottenDays", value: c.Actors.Lifecycle.RottenDays},
+		{field: "actors.lifecycle.closeDays", value: c.Actors.Lifecycle.CloseDays},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%s: must be positive", d.field))
+		}
+	}
+
+	for _, label := range c.Actors.Triage.Labels {
+		if len(strings.TrimSpace(label)) == 0 {
+			errs = multierror.Append(errs, errors.New("actors.triage.labels: empty label name"))
+		}
+	}
+	for _, label := range c.Actors.Lifecycle.ExemptLabels {
+		if len(strings.TrimSpace(label)) == 0 {
+			errs = multierror.Append(errs, errors.New("actors.lifecycle.exemptLabels: empty label name"))
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
+// CommandRole returns the minimum role required to run the command.
+func (c *Config) CommandRole(command string) permission.Role {
+	if role, ok := c.Permissions.Commands[command]; ok {
+		return role
+	}
+
+	return permission.RoleNone
+}
+
+// AuthorCommand reports whether the author of the issue or pull request can always run the command.
+func (c *Config) AuthorCommand(command string) bool {
+	return slices.Contains(c.Permissions.AuthorCommands, command)
+}
+
+// Actor returns the shared configuration of the named actor.
+func (c *Config) Actor(name string) (ActorConfig, bool) {
+	actor, ok := c.actors()[name]
+	return actor, ok
+}
+
+// ActorEnabled reports whether the named actor should handle the GitHub event.
+// Actors without configuration are enabled for all events.
+func (c *Config) ActorEnabled(name, event string) bool {
+	actor, ok := c.Actor(name)
+	if !ok {
+		return true
+	}
+	if actor.Enabled != nil && !*actor.Enabled {
+		return false
+	}
+
+	return len(actor.Events) == 0 || slices.Contains(actor.Events, event)
+}
+
+func (c *Config) actors() map[string]ActorConfig {
+	return map[string]ActorConfig{
+		AssignActor:    c.Actors.Assign.ActorConfig,
+		RetestActor:    c.Actors.Retest.ActorConfig,
+		SyncActor:      c.Actors.Sync.ActorConfig,
+		AreaActor:      c.Actors.Area.ActorConfig,
+		KindActor:      c.Actors.Kind.ActorConfig,
+		TriageActor:    c.Actors.Triage.ActorConfig,
+		LGTMActor:      c.Actors.LGTM.ActorConfig,
+		CCActor:        c.Actors.CC,
+		ApproveActor:   c.Actors.Approve.ActorConfig,
+		HoldActor:      c.Actors.Hold.ActorConfig,
+		StateActor:     c.Actors.State,
+		ClaimActor:     c.Actors.Claim.ActorConfig,
+		LifecycleActor: c.Actors.Lifecycle.ActorConfig,
+		HelpActor:      c.Actors.Help,
+	}
+}
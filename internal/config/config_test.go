@@ -0,0 +1,221 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/permission"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		content   string
+		expect    func() *Config
+		expectErr bool
+	}{
+		{
+			caseName: "Empty config file uses the defaults",
+			content:  "",
+			expect:   Default,
+		},
+		{
+			caseName: "Override actor settings",
+			content: `
+actors:
+  area:
+    prefix: "component/"
+  sync:
+    enabled: false
+  triage:
+    labels: ["kind/question"]
+  assign:
+    othersRole: write
+`,
+			expect: func() *Config {
+				cfg := Default()
+				cfg.Actors.Area.Prefix = "component/"
+				cfg.Actors.Sync.Enabled = github.Ptr(false)
+				cfg.Actors.Triage.Labels = []string{"kind/question"}
+				cfg.Actors.Assign.OthersRole = permission.RoleWrite
+				return cfg
+			},
+		},
+		{
+			caseName: "Restrict the events of an actor",
+			content: `
+actors:
+  retest:
+    events: [issue_comment]
+`,
+			expect: func() *Config {
+				cfg := Default()
+				cfg.Actors.Retest.Events = []string{"issue_comment"}
+				return cfg
+			},
+		},
+		{
+			caseName: "Override the role of commands",
+			content: `
+permissions:
+  commands:
+    sync: write
+    retest: read
+`,
+			expect: func() *Config {
+				cfg := Default()
+				cfg.Permissions.Commands["sync"] = permission.RoleWrite
+				cfg.Permissions.Commands["retest"] = permission.RoleRead
+				return cfg
+			},
+		},
+		{
+			caseName: "Reject unknown roles",
+			content: `
+permissions:
+  commands:
+    sync: owner
+`,
+			expectErr: true,
+		},
+		{
+			caseName: "Reject unknown actors",
+			content: `
+actors:
+  foo:
+    enabled: true
+`,
+			expectErr: true,
+		},
+		{
+			caseName: "Reject unknown fields",
+			content: `
+actors:
+  area:
+    prefixes: "component/"
+`,
+			expectErr: true,
+		},
+		{
+			caseName: "Reject negative limits",
+			content: `
+actors:
+  assign:
+    maxAssignees: -1
+`,
+			expectErr: true,
+		},
+		{
+			caseName: "Reject non positive durations",
+			content: `
+actors:
+  claim:
+    graceDays: 0
+`,
+			expectErr: true,
+		},
+		{
+			caseName: "Reject empty settings",
+			content: `
+actors:
+  kind:
+    prefix: ""
+`,
+			expectErr: true,
+		},
+		{
+			caseName:  "Reject malformed config file",
+			content:   "actors: [",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			cfg, err := Parse([]byte(tc.content))
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, cfg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect(), cfg)
+		})
+	}
+}
+
+func TestActorEnabled(t *testing.T) {
+	cfg := Default()
+	cfg.Actors.Sync.Enabled = github.Ptr(false)
+	cfg.Actors.Retest.Events = []string{"issue_comment"}
+
+	cases := []struct {
+		caseName string
+		actor    string
+		event    string
+		expect   bool
+	}{
+		{
+			caseName: "Actors are enabled by default",
+			actor:    AssignActor,
+			event:    "issue_comment",
+			expect:   true,
+		},
+		{
+			caseName: "Disabled actor",
+			actor:    SyncActor,
+			event:    "issue_comment",
+			expect:   false,
+		},
+		{
+			caseName: "Actor enabled for the event",
+			actor:    RetestActor,
+			event:    "issue_comment",
+			expect:   true,
+		},
+		{
+			caseName: "Actor not enabled for the event",
+			actor:    RetestActor,
+			event:    "pull_request",
+			expect:   false,
+		},
+		{
+			caseName: "Actors without configuration are enabled",
+			actor:    "unknown",
+			event:    "issues",
+			expect:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, cfg.ActorEnabled(tc.actor, tc.event))
+		})
+	}
+}
+
+func TestCommandRole(t *testing.T) {
+	cfg := Default()
+	assert.Equal(t, permission.RoleTriage, cfg.CommandRole("area"))
+	assert.Equal(t, permission.RoleTriage, cfg.CommandRole("lifecycle"))
+	assert.Equal(t, permission.RoleTriage, cfg.CommandRole("remove-lifecycle"))
+	assert.Equal(t, permission.RoleNone, cfg.CommandRole("assign"))
+	assert.True(t, cfg.AuthorCommand("close"))
+	assert.False(t, cfg.AuthorCommand("area"))
+}
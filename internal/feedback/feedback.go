@@ -0,0 +1,104 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package feedback reports the failures of the actors back to the commenter,
+// who would otherwise not know that a command was not applied.
+package feedback
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+// Failure is an actor that failed to handle an event.
+type Failure struct {
+	// Actor is the name of the actor.
+	Actor string
+
+	// Err is the error returned by the actor.
+	Err error
+}
+
+// Report replies to the comment that triggered the failed actors with a single
+// comment explaining what failed, and reacts to it with the "confused" reaction.
+// Failures of events without a commenter are only logged by the caller.
+func Report(ghClient *actors.Client, event actors.GenericEvent, failures []Failure) error {
+	commentEvent, ok := event.Event.(github.IssueCommentEvent)
+	if !ok || len(failures) == 0 {
+		return nil
+	}
+
+	var (
+		repo    = commentEvent.GetRepo().GetFullName()
+		comment = commentEvent.GetComment()
+	)
+	if err := actors.AddComment(
+		ghClient,
+		Message(comment.GetUser().GetLogin(), failures),
+		repo,
+		commentEvent.GetIssue().GetNumber(),
+	); err != nil {
+		return err
+	}
+
+	return actors.AddReaction(ghClient, actors.ConfusedReaction, repo, comment.GetID())
+}
+
+// Message returns the reply to the commenter, one line per failure. The reason of a
+// CommandError is shown with its valid options, other errors are not meant for the
+// commenter and point to the workflow run instead.
+func Message(login string, failures []Failure) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s sorry, your comment could not be fully handled:\n", login)
+	for _, failure := range failures {
+		var commandErr *actors.CommandError
+		if errors.As(failure.Err, &commandErr) {
+			fmt.Fprintf(&b, "\n- `%s`: %s.", commandErr.Command, commandErr.Reason)
+			if len(commandErr.Options) != 0 {
+				fmt.Fprintf(&b, " The valid options are `%s`.", strings.Join(commandErr.Options, "`, `"))
+			}
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n- %s failed unexpectedly", failure.Actor)
+		if url := RunURL(); len(url) != 0 {
+			fmt.Fprintf(&b, ", see the [workflow run](%s) for details.", url)
+		} else {
+			b.WriteString(", see the workflow run for details.")
+		}
+	}
+
+	return b.String()
+}
+
+// RunURL returns the URL of the workflow run from the default environment
+// variables of GitHub Actions, or an empty string outside of a workflow.
+func RunURL() string {
+	var (
+		server     = os.Getenv("GITHUB_SERVER_URL")
+		repository = os.Getenv("GITHUB_REPOSITORY")
+		runID      = os.Getenv("GITHUB_RUN_ID")
+	)
+	if len(server) == 0 || len(repository) == 0 || len(runID) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repository, runID)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/fake"
+)
+
+func TestMessage(t *testing.T) {
+	cases := []struct {
+		caseName string
+		env      map[string]string
+		failures []Failure
+		expect   string
+	}{
+		{
+			caseName: "Command error with options",
+			failures: []Failure{
+				{
+					Actor: "AreaLabelerActor",
+					Err: &actors.CommandError{
+						Command: "/area foo",
+						Reason:  "the `area/foo` label does not exist",
+						Options: []string{"api", "core"},
+					},
+				},
+			},
+			expect: "@alice sorry, your comment could not be fully handled:\n" +
+				"\n- `/area foo`: the `area/foo` label does not exist. The valid options are `api`, `core`.",
+		},
+		{
+			caseName: "Command error without options",
+			failures: []Failure{
+				{Actor: "StateActor", Err: &actors.CommandError{Command: "/close now", Reason: "unknown reason"}},
+			},
+			expect: "@alice sorry, your comment could not be fully handled:\n" +
+				"\n- `/close now`: unknown reason.",
+		},
+		{
+			caseName: "Unexpected error in a workflow run",
+			env: map[string]string{
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "owner/repo",
+				"GITHUB_RUN_ID":     "42",
+			},
+			failures: []Failure{
+				{Actor: "RetestActor", Err: errors.New("502 Bad Gateway")},
+				{Actor: "KindLabelerActor", Err: &actors.CommandError{Command: "/kind foo", Reason: "the `kind/foo` label does not exist"}},
+			},
+			expect: "@alice sorry, your comment could not be fully handled:\n" +
+				"\n- RetestActor failed unexpectedly, see the [workflow run](https://github.com/owner/repo/actions/runs/42) for details." +
+				"\n- `/kind foo`: the `kind/foo` label does not exist.",
+		},
+		{
+			caseName: "Unexpected error outside of a workflow run",
+			env:      map[string]string{"GITHUB_RUN_ID": ""},
+			failures: []Failure{{Actor: "RetestActor", Err: errors.New("502 Bad Gateway")}},
+			expect: "@alice sorry, your comment could not be fully handled:\n" +
+				"\n- RetestActor failed unexpectedly, see the workflow run for details.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			assert.Equal(t, tc.expect, Message("alice", tc.failures))
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	failures := []Failure{{Actor: "AreaLabelerActor", Err: &actors.CommandError{Command: "/area foo", Reason: "the `area/foo` label does not exist"}}}
+	cases := []struct {
+		caseName      string
+		event         any
+		failures      []Failure
+		expectComment bool
+	}{
+		{
+			caseName: "Reply to the commenter",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(1001)), User: &github.User{Login: github.Ptr("alice")}},
+				Issue:   &github.Issue{Number: github.Ptr(1)},
+				Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+			failures:      failures,
+			expectComment: true,
+		},
+		{
+			caseName: "Do not reply without failures",
+			event: github.IssueCommentEvent{
+				Comment: &github.IssueComment{ID: github.Ptr(int64(1001)), User: &github.User{Login: github.Ptr("alice")}},
+				Issue:   &github.Issue{Number: github.Ptr(1)},
+				Repo:    &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+		},
+		{
+			caseName: "Do not reply without a commenter",
+			event:    actors.ScheduleEvent{Schedule: "0 * * * *"},
+			failures: failures,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			gh := fake.New()
+			gh.Issues[1] = &github.Issue{Number: github.Ptr(1), State: github.Ptr("open")}
+
+			err := Report(gh.Client(), actors.GenericEvent{Event: tc.event}, tc.failures)
+			assert.NoError(t, err)
+			if tc.expectComment {
+				assert.Equal(t, []string{Message("alice", tc.failures)}, gh.CommentBodies(1))
+				assert.Equal(t, []string{actors.ConfusedReaction}, gh.Reactions[1001])
+			} else {
+				assert.Empty(t, gh.CommentBodies(1))
+				assert.Empty(t, gh.Reactions)
+			}
+		})
+	}
+}
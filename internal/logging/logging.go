@@ -0,0 +1,139 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging builds the loggers of actbot, rendering the records as GitHub
+// workflow commands in GitHub Actions, as JSON lines on servers or for the console.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gookit/slog"
+	"github.com/gookit/slog/handler"
+)
+
+const (
+	// channelName is the channel of the records of actbot.
+	channelName = "actbot"
+
+	// ActorField is the field of the records logged by an actor, holding the actor name.
+	ActorField = "actor"
+)
+
+// Format is the format of the logs.
+type Format string
+
+const (
+	// ConsoleFormat prints colored lines for humans.
+	ConsoleFormat Format = "console"
+
+	// WorkflowFormat prints GitHub workflow commands, grouping the records of
+	// each actor and annotating the errors and the warnings.
+	WorkflowFormat Format = "workflow"
+
+	// JSONFormat prints a JSON object per record for log collectors.
+	JSONFormat Format = "json"
+)
+
+// ParseFormat parses a format name, such as "json".
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	switch format {
+	case ConsoleFormat, WorkflowFormat, JSONFormat:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown log format '%s'", name)
+	}
+}
+
+// FormatFromEnv returns the format configured by the 'logFormat' variable, by default the
+// workflow format in GitHub Actions and the console format elsewhere.
+func FormatFromEnv(getenv func(string) string) (Format, error) {
+	if name := getenv("logFormat"); len(name) != 0 {
+		return ParseFormat(name)
+	}
+	if getenv("GITHUB_ACTIONS") == "true" {
+		return WorkflowFormat, nil
+	}
+
+	return ConsoleFormat, nil
+}
+
+// NewHandler returns the handler writing all levels in the format, the console
+// format always writes to the standard output.
+func NewHandler(format Format, w io.Writer) slog.Handler {
+	switch format {
+	case WorkflowFormat:
+		return NewWorkflowHandler(w)
+	case JSONFormat:
+		jsonHandler := handler.NewIOWriterHandler(w, slog.AllLevels)
+		jsonHandler.SetFormatter(slog.NewJSONFormatter())
+		return jsonHandler
+	default:
+		return handler.NewConsoleHandler(slog.AllLevels)
+	}
+}
+
+// New returns a logger writing to the handler.
+func New(h slog.Handler) *slog.Logger {
+	return slog.NewWithConfig(func(l *slog.Logger) {
+		l.ChannelName = channelName
+		l.AddHandler(h)
+	})
+}
+
+// WithActor returns a logger writing to the handler and adding the actor name to
+// every record, so that the workflow format can group the records of the actor.
+func WithActor(h slog.Handler, name string) *slog.Logger {
+	l := New(h)
+	l.AddProcessor(slog.ProcessorFunc(func(r *slog.Record) {
+		r.AddField(ActorField, name)
+	}))
+
+	return l
+}
+
+// Mask asks GitHub Actions to hide the secrets in the logs when the handler
+// prints workflow commands, empty secrets are ignored.
+func Mask(h slog.Handler, secrets ...string) {
+	workflowHandler, ok := h.(*WorkflowHandler)
+	if !ok {
+		return
+	}
+	for _, secret := range secrets {
+		if len(secret) != 0 {
+			workflowHandler.Mask(secret)
+		}
+	}
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the logger, the code handling the calls
+// made with the context, such as the retries of the GitHub API, logs with it.
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by the context, or the fallback if it has none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return l
+	}
+
+	return fallback
+}
@@ -0,0 +1,80 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFromEnv(t *testing.T) {
+	cases := []struct {
+		caseName  string
+		env       map[string]string
+		expect    Format
+		expectErr bool
+	}{
+		{
+			caseName: "Console outside of GitHub Actions",
+			expect:   ConsoleFormat,
+		},
+		{
+			caseName: "Workflow commands in GitHub Actions",
+			env:      map[string]string{"GITHUB_ACTIONS": "true"},
+			expect:   WorkflowFormat,
+		},
+		{
+			caseName: "Configured format",
+			env:      map[string]string{"GITHUB_ACTIONS": "true", "logFormat": " JSON "},
+			expect:   JSONFormat,
+		},
+		{
+			caseName:  "Unknown format",
+			env:       map[string]string{"logFormat": "xml"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			format, err := FormatFromEnv(func(key string) string { return tc.env[key] })
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, format)
+		})
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(JSONFormat, &buf)
+	WithActor(h, "retest").Errorf("failed to rerun job %d", 42)
+	// secrets are only masked by GitHub Actions
+	Mask(h, "secret-token")
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "actbot", record["channel"])
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "failed to rerun job 42", record["message"])
+	assert.Equal(t, "retest", record[ActorField])
+	assert.NotContains(t, buf.String(), "secret-token")
+}
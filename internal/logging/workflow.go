@@ -0,0 +1,121 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/gookit/slog"
+)
+
+// WorkflowHandler prints the records as GitHub workflow commands, see
+// https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions
+//
+// The records of an actor are folded in a group named after it, the errors and the
+// warnings are annotated so that they show up in the summary of the workflow run.
+type WorkflowHandler struct {
+	mu sync.Mutex
+	w  io.Writer
+
+	// group is the name of the open group, empty when no group is open.
+	group string
+}
+
+// NewWorkflowHandler returns a handler printing workflow commands to the writer.
+func NewWorkflowHandler(w io.Writer) *WorkflowHandler {
+	return &WorkflowHandler{w: w}
+}
+
+func (h *WorkflowHandler) Handle(r *slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	actor, _ := r.Fields[ActorField].(string)
+	if actor != h.group {
+		if err := h.endGroup(); err != nil {
+			return err
+		}
+		if len(actor) != 0 {
+			if err := h.command("group", actor); err != nil {
+				return err
+			}
+			h.group = actor
+		}
+	}
+
+	switch {
+	case r.Level <= slog.ErrorLevel:
+		return h.command("error", r.Message)
+	case r.Level == slog.WarnLevel:
+		return h.command("warning", r.Message)
+	case r.Level >= slog.DebugLevel:
+		return h.command("debug", r.Message)
+	}
+
+	// every line is prefixed so that the messages cannot inject workflow commands
+	var b strings.Builder
+	for _, line := range strings.Split(r.Message, "\n") {
+		fmt.Fprintf(&b, "[%s] %s\n", r.LevelName(), line)
+	}
+	_, err := io.WriteString(h.w, b.String())
+
+	return err
+}
+
+// Mask asks GitHub Actions to hide the secret in the logs.
+func (h *WorkflowHandler) Mask(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_ = h.command("add-mask", secret)
+}
+
+func (h *WorkflowHandler) IsHandling(slog.Level) bool {
+	return true
+}
+
+// Flush ends the open group.
+func (h *WorkflowHandler) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.endGroup()
+}
+
+func (h *WorkflowHandler) Close() error {
+	return h.Flush()
+}
+
+func (h *WorkflowHandler) endGroup() error {
+	if len(h.group) == 0 {
+		return nil
+	}
+	h.group = ""
+
+	return h.command("endgroup", "")
+}
+
+func (h *WorkflowHandler) command(name, value string) error {
+	_, err := fmt.Fprintf(h.w, "::%s::%s\n", name, escape(value))
+	return err
+}
+
+// escape escapes the value of a workflow command, so that it stays on a single line.
+func escape(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewWorkflowHandler(&buf)
+	logger := New(h)
+	assign := WithActor(h, "assign")
+	area := WithActor(h, "area")
+
+	logger.Infof("dispatching %s event", "issue_comment")
+	assign.Infof("actor %s started processing events", "AssignActor")
+	assign.Debugf("issue #%d has no assignees", 1)
+	area.Warnf("label '%s' is unknown", "area/foo")
+	area.Errorf("failed to add labels:\n100%% broken")
+	logger.Info("multi-line message\n::error::injected")
+	Mask(h, "secret-token", "")
+	area.Info("grouped again")
+	assert.NoError(t, h.Flush())
+	assert.NoError(t, h.Flush())
+	// closing the logger ends the open group
+	area.Info("closed")
+	assert.NoError(t, logger.Close())
+
+	assert.Equal(t, "[INFO] dispatching issue_comment event\n"+
+		"::group::assign\n"+
+		"[INFO] actor AssignActor started processing events\n"+
+		"::debug::issue #1 has no assignees\n"+
+		"::endgroup::\n"+
+		"::group::area\n"+
+		"::warning::label 'area/foo' is unknown\n"+
+		"::error::failed to add labels:%0A100%25 broken\n"+
+		"::endgroup::\n"+
+		"[INFO] multi-line message\n"+
+		"[INFO] ::error::injected\n"+
+		"::add-mask::secret-token\n"+
+		"::group::area\n"+
+		"[INFO] grouped again\n"+
+		"::endgroup::\n"+
+		"::group::area\n"+
+		"[INFO] closed\n"+
+		"::endgroup::\n", buf.String())
+}
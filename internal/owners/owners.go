@@ -0,0 +1,227 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owners
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileName is the name of the files defining the owners of a directory.
+	FileName = "OWNERS"
+
+	// AliasesFileName is the name of the file, at the repository root,
+	// defining aliases for groups of users.
+	AliasesFileName = "OWNERS_ALIASES"
+)
+
+// File is a Kubernetes style OWNERS file, see
+// https://www.kubernetes.dev/docs/guide/owners/
+type File struct {
+	// Approvers can approve the changes of the directory and its subdirectories.
+	Approvers []string `yaml:"approvers"`
+
+	// Reviewers can review the changes of the directory and its subdirectories.
+	Reviewers []string `yaml:"reviewers"`
+
+	// Labels are applied to the pull requests changing the directory.
+	Labels []string `yaml:"labels"`
+
+	Options FileOptions `yaml:"options"`
+}
+
+type FileOptions struct {
+	// NoParentOwners stops the owners of the parent directories from applying to the directory.
+	NoParentOwners bool `yaml:"no_parent_owners"`
+}
+
+// aliasesFile is the content of the OWNERS_ALIASES file.
+type aliasesFile struct {
+	Aliases map[string][]string `yaml:"aliases"`
+}
+
+// Owners holds the OWNERS files of a repository, keyed by the directory they are in,
+// the repository root directory being ".". A nil Owners has no OWNERS files.
+type Owners struct {
+	files map[string]File
+}
+
+// ParseAliases parses the content of an OWNERS_ALIASES file.
+func ParseAliases(data []byte) (map[string][]string, error) {
+	var aliases aliasesFile
+	if err := decode(data, &aliases); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", AliasesFileName, err)
+	}
+
+	return aliases.Aliases, nil
+}
+
+// ParseFile parses the content of an OWNERS file, expanding the aliases it refers to.
+func ParseFile(data []byte, aliases map[string][]string) (File, error) {
+	var file File
+	if err := decode(data, &file); err != nil {
+		return File{}, fmt.Errorf("parse %s: %w", FileName, err)
+	}
+
+	file.Approvers = expand(file.Approvers, aliases)
+	file.Reviewers = expand(file.Reviewers, aliases)
+
+	return file, nil
+}
+
+// New builds the owners of a repository from its OWNERS files keyed by their path,
+// such as "OWNERS" or "pkg/api/OWNERS", and the content of its OWNERS_ALIASES file, if any.
+func New(files map[string][]byte, aliases []byte) (*Owners, error) {
+	var (
+		aliasMap map[string][]string
+		err      error
+	)
+	if aliases != nil {
+		if aliasMap, err = ParseAliases(aliases); err != nil {
+			return nil, err
+		}
+	}
+
+	owners := &Owners{files: make(map[string]File, len(files))}
+	for filePath, data := range files {
+		file, err := ParseFile(data, aliasMap)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+		owners.files[path.Dir(path.Clean(filePath))] = file
+	}
+
+	return owners, nil
+}
+
+// Approvers returns the approvers of the file at the given path,
+// which are the approvers of its directory and, unless stopped by
+// 'no_parent_owners', of the parent directories.
+func (o *Owners) Approvers(filePath string) []string {
+	return o.collect(filePath, func(file File) []string { return file.Approvers })
+}
+
+// Reviewers returns the reviewers of the file at the given path,
+// which are collected in the same way as the approvers.
+func (o *Owners) Reviewers(filePath string) []string {
+	return o.collect(filePath, func(file File) []string { return file.Reviewers })
+}
+
+// LeafApprovers returns the approvers of the OWNERS file closest to the file at the given path.
+func (o *Owners) LeafApprovers(filePath string) []string {
+	dir, ok := o.OwnersDir(filePath)
+	if !ok {
+		return nil
+	}
+
+	return o.files[dir].Approvers
+}
+
+// OwnersDir returns the directory of the OWNERS file closest to the file at the given path.
+func (o *Owners) OwnersDir(filePath string) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+
+	for _, dir := range parentDirs(filePath) {
+		if _, ok := o.files[dir]; ok {
+			return dir, true
+		}
+	}
+
+	return "", false
+}
+
+// IsApprover reports whether the user is an approver of the file at the given path.
+func (o *Owners) IsApprover(login, filePath string) bool {
+	return slices.Contains(o.Approvers(filePath), strings.ToLower(login))
+}
+
+// IsReviewer reports whether the user is a reviewer of the file at the given path.
+func (o *Owners) IsReviewer(login, filePath string) bool {
+	return slices.Contains(o.Reviewers(filePath), strings.ToLower(login))
+}
+
+func (o *Owners) collect(filePath string, users func(File) []string) []string {
+	if o == nil {
+		return nil
+	}
+
+	var ret []string
+	for _, dir := range parentDirs(filePath) {
+		file, ok := o.files[dir]
+		if !ok {
+			continue
+		}
+		for _, user := range users(file) {
+			if !slices.Contains(ret, user) {
+				ret = append(ret, user)
+			}
+		}
+		if file.Options.NoParentOwners {
+			break
+		}
+	}
+
+	return ret
+}
+
+// parentDirs returns the directory of the file at the given path
+// followed by its parent directories, up to the repository root ".".
+func parentDirs(filePath string) []string {
+	var dirs []string
+	for dir := path.Dir(path.Clean(strings.TrimPrefix(filePath, "/"))); ; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == "." || dir == "/" {
+			return dirs
+		}
+	}
+}
+
+// expand replaces the aliases by their members, the GitHub logins
+// are case-insensitive and are therefore returned in lower case.
+func expand(users []string, aliases map[string][]string) []string {
+	var ret []string
+	for _, user := range users {
+		members, ok := aliases[user]
+		if !ok {
+			members = []string{user}
+		}
+		for _, member := range members {
+			member = strings.ToLower(strings.TrimSpace(member))
+			if len(member) != 0 && !slices.Contains(ret, member) {
+				ret = append(ret, member)
+			}
+		}
+	}
+
+	return ret
+}
+
+func decode(data []byte, out any) error {
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
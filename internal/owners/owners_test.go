@@ -0,0 +1,150 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOwners(t *testing.T) *Owners {
+	t.Helper()
+
+	owners, err := New(
+		map[string][]byte{
+			"OWNERS": []byte(`
+approvers:
+  - root-approvers
+reviewers:
+  - Alice
+`),
+			"pkg/api/OWNERS": []byte(`
+approvers:
+  - bob
+reviewers:
+  - carol
+`),
+			"pkg/api/v1/OWNERS": []byte(`
+approvers:
+  - dave
+`),
+			"docs/OWNERS": []byte(`
+options:
+  no_parent_owners: true
+approvers:
+  - erin
+emeritus_approvers:
+  - frank
+`),
+		},
+		[]byte(`
+aliases:
+  root-approvers:
+    - Maintainer1
+    - maintainer2
+`),
+	)
+	assert.NoError(t, err)
+
+	return owners
+}
+
+func TestApprovers(t *testing.T) {
+	owners := newTestOwners(t)
+
+	cases := []struct {
+		caseName string
+		path     string
+		expect   []string
+	}{
+		{
+			caseName: "Root approvers with expanded aliases",
+			path:     "main.go",
+			expect:   []string{"maintainer1", "maintainer2"},
+		},
+		{
+			caseName: "Approvers of the parent directories",
+			path:     "pkg/api/v1/types.go",
+			expect:   []string{"dave", "bob", "maintainer1", "maintainer2"},
+		},
+		{
+			caseName: "Directory without OWNERS file inherits the parent approvers",
+			path:     "pkg/util/strings.go",
+			expect:   []string{"maintainer1", "maintainer2"},
+		},
+		{
+			caseName: "Parent approvers do not apply with no_parent_owners",
+			path:     "docs/guide/README.md",
+			expect:   []string{"erin"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, owners.Approvers(tc.path))
+		})
+	}
+}
+
+func TestReviewers(t *testing.T) {
+	owners := newTestOwners(t)
+
+	assert.Equal(t, []string{"carol", "alice"}, owners.Reviewers("pkg/api/server.go"))
+	assert.True(t, owners.IsReviewer("ALICE", "pkg/api/server.go"))
+	assert.False(t, owners.IsReviewer("carol", "main.go"))
+}
+
+func TestLeafApprovers(t *testing.T) {
+	owners := newTestOwners(t)
+
+	dir, ok := owners.OwnersDir("pkg/api/v1/types.go")
+	assert.True(t, ok)
+	assert.Equal(t, "pkg/api/v1", dir)
+	assert.Equal(t, []string{"dave"}, owners.LeafApprovers("pkg/api/v1/types.go"))
+
+	dir, ok = owners.OwnersDir("go.mod")
+	assert.True(t, ok)
+	assert.Equal(t, ".", dir)
+
+	assert.True(t, owners.IsApprover("Maintainer2", "pkg/api/v1/types.go"))
+	assert.False(t, owners.IsApprover("dave", "pkg/api/server.go"))
+}
+
+func TestNewWithoutOwnersFiles(t *testing.T) {
+	owners, err := New(nil, nil)
+	assert.NoError(t, err)
+
+	_, ok := owners.OwnersDir("main.go")
+	assert.False(t, ok)
+	assert.Empty(t, owners.Approvers("main.go"))
+}
+
+func TestNewWithInvalidOwnersFile(t *testing.T) {
+	_, err := New(map[string][]byte{"OWNERS": []byte("approvers: [")}, nil)
+	assert.Error(t, err)
+
+	_, err = New(nil, []byte("aliases: foo"))
+	assert.Error(t, err)
+}
+
+func TestNilOwners(t *testing.T) {
+	var owners *Owners
+
+	_, ok := owners.OwnersDir("main.go")
+	assert.False(t, ok)
+	assert.Empty(t, owners.LeafApprovers("main.go"))
+	assert.False(t, owners.IsApprover("alice", "main.go"))
+}
@@ -0,0 +1,176 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permission
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+
+	"github.com/ShyunnY/actbot/internal/owners"
+)
+
+// Role is the role of a user in a repository, see
+// https://docs.github.com/en/organizations/managing-user-access-to-your-organizations-repositories/managing-repository-roles/repository-roles-for-an-organization
+type Role string
+
+const (
+	RoleNone     Role = "none"
+	RoleRead     Role = "read"
+	RoleTriage   Role = "triage"
+	RoleWrite    Role = "write"
+	RoleMaintain Role = "maintain"
+	RoleAdmin    Role = "admin"
+)
+
+// roleRanks orders the roles from the least to the most privileged.
+var roleRanks = map[Role]int{
+	RoleNone:     0,
+	RoleRead:     1,
+	RoleTriage:   2,
+	RoleWrite:    3,
+	RoleMaintain: 4,
+	RoleAdmin:    5,
+}
+
+// ParseRole parses a role name, such as "triage".
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role '%s'", name)
+	}
+
+	return role, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so that
+// config files are rejected when they have an unknown role.
+func (r *Role) UnmarshalText(text []byte) error {
+	role, err := ParseRole(string(text))
+	if err != nil {
+		return err
+	}
+	*r = role
+
+	return nil
+}
+
+// AtLeast reports whether the role is at least as privileged as the minimum role.
+func (r Role) AtLeast(minimum Role) bool {
+	return roleRanks[r] >= roleRanks[minimum]
+}
+
+// RepositoriesService reads the permissions of the repository collaborators,
+// it is implemented by the go-github repositories service.
+type RepositoriesService interface {
+	GetPermissionLevel(ctx context.Context, owner, repo, user string) (*github.RepositoryPermissionLevel, *github.Response, error)
+}
+
+// Checker resolves the roles of users in a repository,
+// the roles are cached as a single run usually checks the same commenter several times.
+type Checker struct {
+	repositories RepositoriesService
+	repoFullName string
+
+	// repoOwners are the OWNERS files of the repository, the approvers and reviewers
+	// of the root directory are granted the write and triage roles respectively.
+	repoOwners *owners.Owners
+
+	roles map[string]Role
+}
+
+func NewChecker(repositories RepositoriesService, repoFullName string, repoOwners *owners.Owners) *Checker {
+	return &Checker{
+		repositories: repositories,
+		repoFullName: repoFullName,
+		repoOwners:   repoOwners,
+		roles:        make(map[string]Role),
+	}
+}
+
+// Role returns the role of the user in the repository,
+// users that are unknown to the repository have no role.
+func (c *Checker) Role(login string) (Role, error) {
+	if role, ok := c.roles[login]; ok {
+		return role, nil
+	}
+
+	role, err := c.collaboratorRole(login)
+	if err != nil {
+		return "", err
+	}
+	if ownersRole := c.ownersRole(login); !role.AtLeast(ownersRole) {
+		role = ownersRole
+	}
+	c.roles[login] = role
+
+	return role, nil
+}
+
+// HasRole reports whether the user has at least the minimum role in the repository.
+func (c *Checker) HasRole(login string, minimum Role) (bool, error) {
+	if minimum == RoleNone {
+		return true, nil
+	}
+	// avoid requesting GitHub when the OWNERS files already grant the role
+	if c.ownersRole(login).AtLeast(minimum) {
+		return true, nil
+	}
+
+	role, err := c.Role(login)
+	if err != nil {
+		return false, err
+	}
+
+	return role.AtLeast(minimum), nil
+}
+
+// ownersRole returns the role granted to the user by the root OWNERS file.
+func (c *Checker) ownersRole(login string) Role {
+	switch {
+	case c.repoOwners.IsApprover(login, owners.FileName):
+		return RoleWrite
+	case c.repoOwners.IsReviewer(login, owners.FileName):
+		return RoleTriage
+	default:
+		return RoleNone
+	}
+}
+
+// collaboratorRole returns the role of the user granted by the repository collaborator settings.
+func (c *Checker) collaboratorRole(login string) (Role, error) {
+	owner, repo, _ := strings.Cut(c.repoFullName, "/")
+	level, resp, err := c.repositories.GetPermissionLevel(context.Background(), owner, repo, login)
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return RoleNone, nil
+	case err != nil:
+		return "", fmt.Errorf("get permission of '%s': %w", login, err)
+	}
+
+	// The role name also covers the triage and maintain roles, which are reported
+	// as read and write permissions, custom roles fall back to their base permission.
+	role, err := ParseRole(level.GetRoleName())
+	if err != nil {
+		if role, err = ParseRole(level.GetPermission()); err != nil {
+			role = RoleNone
+		}
+	}
+
+	return role, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permission
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/owners"
+)
+
+func TestRoleAtLeast(t *testing.T) {
+	cases := []struct {
+		caseName string
+		role     Role
+		minimum  Role
+		expect   bool
+	}{
+		{
+			caseName: "Same role",
+			role:     RoleTriage,
+			minimum:  RoleTriage,
+			expect:   true,
+		},
+		{
+			caseName: "More privileged role",
+			role:     RoleMaintain,
+			minimum:  RoleWrite,
+			expect:   true,
+		},
+		{
+			caseName: "Less privileged role",
+			role:     RoleRead,
+			minimum:  RoleTriage,
+			expect:   false,
+		},
+		{
+			caseName: "Every role satisfies none",
+			role:     RoleNone,
+			minimum:  RoleNone,
+			expect:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.role.AtLeast(tc.minimum))
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Maintain ")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleMaintain, role)
+
+	_, err = ParseRole("owner")
+	assert.Error(t, err)
+}
+
+func TestCheckerRole(t *testing.T) {
+	cases := []struct {
+		caseName   string
+		login      string
+		permission string
+		roleName   string
+		status     int
+		expect     Role
+		expectErr  bool
+	}{
+		{
+			caseName:   "Role name takes precedence over permission",
+			login:      "triager",
+			permission: "read",
+			roleName:   "triage",
+			status:     http.StatusOK,
+			expect:     RoleTriage,
+		},
+		{
+			caseName:   "Custom role falls back to permission",
+			login:      "custom",
+			permission: "write",
+			roleName:   "release-manager",
+			status:     http.StatusOK,
+			expect:     RoleWrite,
+		},
+		{
+			caseName: "Unknown user has no role",
+			login:    "ghost",
+			status:   http.StatusNotFound,
+			expect:   RoleNone,
+		},
+		{
+			caseName:  "Server error",
+			login:     "someone",
+			status:    http.StatusInternalServerError,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf("/repos/owner/repo/collaborators/%s/permission", tc.login)))
+				w.WriteHeader(tc.status)
+				_, _ = fmt.Fprintf(w, `{"permission":%q,"role_name":%q}`, tc.permission, tc.roleName)
+			}))
+			defer server.Close()
+
+			ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+			assert.NoError(t, err)
+
+			checker := NewChecker(ghClient.Repositories, "owner/repo", nil)
+			role, err := checker.Role(tc.login)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, role)
+
+			// the role is cached for the following checks
+			_, err = checker.Role(tc.login)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, requests)
+		})
+	}
+}
+
+func TestCheckerOwnersRole(t *testing.T) {
+	repoOwners, err := owners.New(
+		map[string][]byte{
+			"OWNERS":     []byte("approvers: [alice]\nreviewers: [bob]\n"),
+			"pkg/OWNERS": []byte("approvers: [carol]\n"),
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"permission":"read","role_name":"read"}`)
+	}))
+	defer server.Close()
+
+	ghClient, err := github.NewClient(nil).WithEnterpriseURLs(server.URL, server.URL)
+	assert.NoError(t, err)
+	checker := NewChecker(ghClient.Repositories, "owner/repo", repoOwners)
+
+	cases := []struct {
+		caseName string
+		login    string
+		expect   Role
+	}{
+		{
+			caseName: "Root approvers have the write role",
+			login:    "alice",
+			expect:   RoleWrite,
+		},
+		{
+			caseName: "Root reviewers have the triage role",
+			login:    "bob",
+			expect:   RoleTriage,
+		},
+		{
+			caseName: "Subdirectory approvers keep their repository role",
+			login:    "carol",
+			expect:   RoleRead,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			role, err := checker.Role(tc.login)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, role)
+		})
+	}
+}
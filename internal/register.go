@@ -15,31 +15,94 @@
 package internal
 
 import (
-	"github.com/google/go-github/v72/github"
 	"github.com/gookit/slog"
 
 	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/actors/approve"
 	"github.com/ShyunnY/actbot/internal/actors/area"
 	"github.com/ShyunnY/actbot/internal/actors/assign"
+	"github.com/ShyunnY/actbot/internal/actors/cc"
+	"github.com/ShyunnY/actbot/internal/actors/claim"
+	"github.com/ShyunnY/actbot/internal/actors/help"
+	"github.com/ShyunnY/actbot/internal/actors/hold"
 	"github.com/ShyunnY/actbot/internal/actors/kind"
+	"github.com/ShyunnY/actbot/internal/actors/lgtm"
+	"github.com/ShyunnY/actbot/internal/actors/lifecycle"
 	"github.com/ShyunnY/actbot/internal/actors/retest"
+	"github.com/ShyunnY/actbot/internal/actors/state"
 	"github.com/ShyunnY/actbot/internal/actors/sync"
+	"github.com/ShyunnY/actbot/internal/actors/triage"
+	"github.com/ShyunnY/actbot/internal/config"
 )
 
 type GitHubEventType string
 
-type RegisterFn = func(ghClient *github.Client, logger *slog.Logger, opts *actors.Options) actors.Actor
+type RegisterFn = func(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor
+
+// registration binds an actor constructor to the name
+// used to configure the actor in the repository config file.
+type registration struct {
+	name string
+	fn   RegisterFn
+}
 
 const (
-	IssueComment GitHubEventType = "issue_comment"
+	IssueComment      GitHubEventType = "issue_comment"
+	Issues            GitHubEventType = "issues"
+	PullRequest       GitHubEventType = "pull_request"
+	PullRequestTarget GitHubEventType = "pull_request_target"
+	Schedule          GitHubEventType = "schedule"
 )
 
-var actorMap = map[GitHubEventType][]RegisterFn{
+var actorMap = map[GitHubEventType][]registration{
 	IssueComment: {
-		assign.NewAssignActor,
-		retest.NewRetestActor,
-		sync.NewSyncActor,
-		area.NewLabelerActor,
-		kind.NewLabelerActor,
+		{name: config.AssignActor, fn: assign.NewAssignActor},
+		{name: config.RetestActor, fn: retest.NewRetestActor},
+		{name: config.SyncActor, fn: sync.NewSyncActor},
+		{name: config.AreaActor, fn: area.NewLabelerActor},
+		{name: config.KindActor, fn: kind.NewLabelerActor},
+		{name: config.LGTMActor, fn: lgtm.NewLGTMActor},
+		{name: config.CCActor, fn: cc.NewCCActor},
+		{name: config.ApproveActor, fn: approve.NewApproveActor},
+		{name: config.HoldActor, fn: hold.NewHoldActor},
+		{name: config.StateActor, fn: state.NewStateActor},
+		{name: config.LifecycleActor, fn: lifecycle.NewLifecycleActor},
+	},
+	Issues: {
+		{name: config.TriageActor, fn: triage.NewTriageActor},
+	},
+	PullRequest: {
+		{name: config.LGTMActor, fn: lgtm.NewLGTMActor},
+		{name: config.HoldActor, fn: hold.NewHoldActor},
+	},
+	PullRequestTarget: {
+		{name: config.LGTMActor, fn: lgtm.NewLGTMActor},
+		{name: config.HoldActor, fn: hold.NewHoldActor},
+	},
+	Schedule: {
+		{name: config.ClaimActor, fn: claim.NewClaimActor},
+		{name: config.LifecycleActor, fn: lifecycle.NewLifecycleActor},
 	},
 }
+
+func init() {
+	// the help actor lists the commands of the other actors handling comments,
+	// it is registered last as it cannot refer to actorMap while it is initialized.
+	actorMap[IssueComment] = append(actorMap[IssueComment], registration{name: config.HelpActor, fn: newHelpActor})
+}
+
+// newHelpActor returns the '/help' actor with the commands of the actors
+// handling comments that are enabled by the repository config.
+func newHelpActor(ghClient *actors.Client, logger *slog.Logger, opts *actors.Options) actors.Actor {
+	var commands []actors.CommandHelp
+	for _, reg := range actorMap[IssueComment] {
+		if reg.name == config.HelpActor || !opts.Config.ActorEnabled(reg.name, string(IssueComment)) {
+			continue
+		}
+		if documented, ok := reg.fn(ghClient, logger, opts).(actors.DocumentedActor); ok {
+			commands = append(commands, documented.Help()...)
+		}
+	}
+
+	return help.NewHelpActor(ghClient, logger, opts, commands)
+}
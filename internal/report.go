@@ -0,0 +1,160 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+	"github.com/ShyunnY/actbot/internal/feedback"
+)
+
+// Outcome is what an actor registered for the dispatched event did with it.
+type Outcome string
+
+const (
+	// Disabled actors are turned off for the event by the config.
+	Disabled Outcome = "disabled"
+
+	// Skipped actors did not capture the event.
+	Skipped Outcome = "skipped"
+
+	// Denied actors captured commands the commenter is not allowed to run.
+	Denied Outcome = "denied"
+
+	// Handled actors successfully handled the event.
+	Handled Outcome = "handled"
+
+	// Failed actors returned an error.
+	Failed Outcome = "failed"
+)
+
+// Result is the outcome of an actor.
+type Result struct {
+	// Actor is the name of the actor.
+	Actor string
+
+	// Outcome is what the actor did with the event.
+	Outcome Outcome
+
+	// Commands are the commands captured by the actor, such as "/area core".
+	Commands []string
+
+	// Err is the error of a failed actor.
+	Err error
+}
+
+// Report is the outcome of every actor registered for the dispatched event, in the order they ran.
+type Report struct {
+	// Event is the name of the dispatched event, such as 'issue_comment'.
+	Event string
+
+	// Sender is the login of the user that triggered the event, such as the commenter.
+	Sender string
+
+	// Number is the number of the issue or pull request of the event, 0 for scheduled events.
+	Number int
+
+	// Results are the outcomes of the actors.
+	Results []Result
+
+	// Actions are the changes made in the repository.
+	Actions []actors.Action
+}
+
+func (r *Report) add(actor actors.Actor, outcome Outcome, err error) {
+	result := Result{Actor: actor.Name(), Outcome: outcome, Err: err}
+	if commandActor, ok := actor.(actors.CommandActor); ok && outcome != Disabled && outcome != Skipped {
+		for _, command := range commandActor.Commands() {
+			result.Commands = append(result.Commands, command.String())
+		}
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Captured returns the results of the actors that captured the event.
+func (r *Report) Captured() []Result {
+	var captured []Result
+	for _, result := range r.Results {
+		if result.Outcome != Disabled && result.Outcome != Skipped {
+			captured = append(captured, result)
+		}
+	}
+
+	return captured
+}
+
+// ActionValues returns the values of the changes of the kind, such as the added labels.
+func (r *Report) ActionValues(kind string) []string {
+	var values []string
+	for _, action := range r.Actions {
+		if action.Kind == kind {
+			values = append(values, action.Value)
+		}
+	}
+
+	return values
+}
+
+// subject returns the user that triggered the event and the number of its issue or pull request.
+func subject(evt any) (string, int) {
+	switch evt := evt.(type) {
+	case github.IssueCommentEvent:
+		return evt.GetComment().GetUser().GetLogin(), evt.GetIssue().GetNumber()
+	case github.IssuesEvent:
+		return evt.GetSender().GetLogin(), evt.GetIssue().GetNumber()
+	case github.PullRequestEvent:
+		return evt.GetSender().GetLogin(), evt.GetNumber()
+	default:
+		return "", 0
+	}
+}
+
+// Count returns the number of actors with the outcome.
+func (r *Report) Count(outcome Outcome) int {
+	var count int
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Failures returns the failed actors.
+func (r *Report) Failures() []feedback.Failure {
+	var failures []feedback.Failure
+	for _, result := range r.Results {
+		if result.Outcome == Failed {
+			failures = append(failures, feedback.Failure{Actor: result.Actor, Err: result.Err})
+		}
+	}
+
+	return failures
+}
+
+// Err returns the errors of the failed actors, or nil if none failed.
+func (r *Report) Err() error {
+	var errs *multierror.Error
+	for _, failure := range r.Failures() {
+		errs = multierror.Append(errs, fmt.Errorf("actor %s: %w", failure.Actor, failure.Err))
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/feedback"
+)
+
+func TestReport(t *testing.T) {
+	var (
+		retestErr = errors.New("502 Bad Gateway")
+		syncErr   = errors.New("dingtalk is unreachable")
+	)
+	cases := []struct {
+		caseName       string
+		results        []Result
+		expectHandled  int
+		expectFailures []feedback.Failure
+		expectErr      string
+	}{
+		{
+			caseName:      "No failures",
+			results:       []Result{{Actor: "AssignActor", Outcome: Handled}, {Actor: "RetestActor", Outcome: Skipped}},
+			expectHandled: 1,
+		},
+		{
+			caseName: "Failures are aggregated",
+			results: []Result{
+				{Actor: "RetestActor", Outcome: Failed, Err: retestErr},
+				{Actor: "AssignActor", Outcome: Handled},
+				{Actor: "SyncActor", Outcome: Failed, Err: syncErr},
+				{Actor: "AreaLabelerActor", Outcome: Denied},
+			},
+			expectHandled: 1,
+			expectFailures: []feedback.Failure{
+				{Actor: "RetestActor", Err: retestErr},
+				{Actor: "SyncActor", Err: syncErr},
+			},
+			expectErr: "2 errors occurred:\n\t* actor RetestActor: 502 Bad Gateway\n\t* actor SyncActor: dingtalk is unreachable\n\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			report := &Report{Event: string(IssueComment), Results: tc.results}
+
+			assert.Equal(t, tc.expectHandled, report.Count(Handled))
+			assert.Equal(t, tc.expectFailures, report.Failures())
+			err := report.Err()
+			if len(tc.expectErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectErr)
+			assert.ErrorIs(t, err, retestErr)
+		})
+	}
+}
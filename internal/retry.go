@@ -0,0 +1,198 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+
+	"github.com/ShyunnY/actbot/internal/logging"
+)
+
+const (
+	// maxRetries is the number of times a request is retried before giving up.
+	maxRetries = 3
+
+	// baseBackoff is the wait before the first retry of a transient failure,
+	// it doubles on every retry.
+	baseBackoff = time.Second
+
+	// maxWait is the longest wait before a retry, a rate limit resetting later
+	// is returned to the caller rather than holding the workflow.
+	maxWait = 2 * time.Minute
+)
+
+// idempotentMethods are the methods retried on transient failures, the other ones
+// may have been applied by GitHub and are only retried when rate limited.
+var idempotentMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// retryTransport retries the requests rejected by the primary or the secondary rate limits
+// of GitHub, and the idempotent requests failing with a transient server or network error.
+type retryTransport struct {
+	base http.RoundTripper
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(base http.RoundTripper) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		base:  base,
+		now:   time.Now,
+		sleep: sleep,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && resp.StatusCode >= http.StatusBadRequest {
+			// buffer the error so that it can be inspected and still returned to the caller
+			data, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return nil, readErr
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+		}
+		if attempt == maxRetries {
+			return resp, err
+		}
+
+		wait, retry := t.backoff(req, resp, err, attempt)
+		if !retry || wait > maxWait {
+			return resp, err
+		}
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		// the retries are logged with the logger of the actor making the call, if any
+		retryLogger := logging.FromContext(req.Context(), logger)
+		if err != nil {
+			retryLogger.Warnf("github %s %s failed by err: %v, retrying in %s (%d/%d)", req.Method, req.URL.Path, err, wait, attempt+1, maxRetries)
+		} else {
+			retryLogger.Warnf("github %s %s responded %d, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, maxRetries)
+		}
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before retrying the request, and whether it should be retried.
+func (t *retryTransport) backoff(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	idempotent := slices.Contains(idempotentMethods, req.Method)
+	if err != nil {
+		// a canceled request is not retried, other network errors are transient
+		if req.Context().Err() != nil {
+			return 0, false
+		}
+		return jitter(attempt), idempotent
+	}
+
+	var (
+		rateLimitErr      *github.RateLimitError
+		abuseRateLimitErr *github.AbuseRateLimitError
+	)
+	switch checkErr := github.CheckResponse(resp); {
+	case errors.As(checkErr, &abuseRateLimitErr):
+		if abuseRateLimitErr.RetryAfter != nil {
+			return max(*abuseRateLimitErr.RetryAfter, 0), true
+		}
+		// GitHub recommends to wait at least one minute without a hint
+		return time.Minute + jitter(attempt), true
+	case errors.As(checkErr, &rateLimitErr):
+		return max(rateLimitErr.Rate.Reset.Sub(t.now()), 0) + time.Second, true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if wait, ok := t.retryAfter(resp); ok {
+			return wait, true
+		}
+		return jitter(attempt), true
+	case resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+		if wait, ok := t.retryAfter(resp); ok {
+			return wait, idempotent
+		}
+		return jitter(attempt), idempotent
+	}
+
+	return 0, false
+}
+
+// retryAfter returns the wait asked by the 'Retry-After' or the 'X-RateLimit-Reset' header of the response.
+func (t *retryTransport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(max(seconds, 0)) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return max(date.Sub(t.now()), 0), true
+		}
+	}
+	if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(t.now()), 0) + time.Second, true
+		}
+	}
+
+	return 0, false
+}
+
+// jitter returns an exponential backoff with a random half, so that the
+// concurrent workflows of a repository do not retry at the same time.
+func jitter(attempt int) time.Duration {
+	backoff := baseBackoff << attempt
+
+	return backoff/2 + rand.N(backoff/2)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/logging"
+)
+
+type response struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+func TestRetryTransport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		caseName    string
+		method      string
+		body        string
+		responses   []response
+		expectCode  int
+		expectCalls int
+		expectWaits []time.Duration
+	}{
+		{
+			caseName:    "Do not retry a successful request",
+			method:      http.MethodGet,
+			responses:   []response{{status: http.StatusOK}},
+			expectCode:  http.StatusOK,
+			expectCalls: 1,
+		},
+		{
+			caseName:    "Do not retry a not found request",
+			method:      http.MethodGet,
+			responses:   []response{{status: http.StatusNotFound}},
+			expectCode:  http.StatusNotFound,
+			expectCalls: 1,
+		},
+		{
+			caseName: "Wait for the primary rate limit to reset",
+			method:   http.MethodPost,
+			body:     `{"body":"hello"}`,
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{
+						"X-RateLimit-Remaining": "0",
+						"X-RateLimit-Reset":     strconv.FormatInt(now.Add(30*time.Second).Unix(), 10),
+					},
+					body: `{"message":"API rate limit exceeded"}`,
+				},
+				{status: http.StatusCreated},
+			},
+			expectCode:  http.StatusCreated,
+			expectCalls: 2,
+			expectWaits: []time.Duration{31 * time.Second},
+		},
+		{
+			caseName: "Wait for the secondary rate limit to be lifted",
+			method:   http.MethodPost,
+			body:     `{"labels":["lgtm"]}`,
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{"Retry-After": "10"},
+					body:   `{"message":"You have exceeded a secondary rate limit","documentation_url":"https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`,
+				},
+				{status: http.StatusOK},
+			},
+			expectCode:  http.StatusOK,
+			expectCalls: 2,
+			expectWaits: []time.Duration{10 * time.Second},
+		},
+		{
+			caseName: "Honor the Retry-After header of too many requests",
+			method:   http.MethodDelete,
+			responses: []response{
+				{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "5"}},
+				{status: http.StatusNoContent},
+			},
+			expectCode:  http.StatusNoContent,
+			expectCalls: 2,
+			expectWaits: []time.Duration{5 * time.Second},
+		},
+		{
+			caseName: "Retry an idempotent request on a bad gateway",
+			method:   http.MethodGet,
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusServiceUnavailable, header: map[string]string{"Retry-After": "2"}},
+				{status: http.StatusOK},
+			},
+			expectCode:  http.StatusOK,
+			expectCalls: 3,
+			expectWaits: []time.Duration{-1, 2 * time.Second},
+		},
+		{
+			caseName:    "Do not retry a non-idempotent request on a bad gateway",
+			method:      http.MethodPost,
+			body:        `{"body":"hello"}`,
+			responses:   []response{{status: http.StatusBadGateway}},
+			expectCode:  http.StatusBadGateway,
+			expectCalls: 1,
+		},
+		{
+			caseName: "Give up after the maximum number of retries",
+			method:   http.MethodGet,
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusBadGateway},
+				{status: http.StatusBadGateway},
+				{status: http.StatusBadGateway},
+			},
+			expectCode:  http.StatusBadGateway,
+			expectCalls: 4,
+			expectWaits: []time.Duration{-1, -1, -1},
+		},
+		{
+			caseName: "Do not wait for a rate limit resetting too late",
+			method:   http.MethodGet,
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{
+						"X-RateLimit-Remaining": "0",
+						"X-RateLimit-Reset":     strconv.FormatInt(now.Add(time.Hour).Unix(), 10),
+					},
+				},
+			},
+			expectCode:  http.StatusForbidden,
+			expectCalls: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.body, string(body))
+
+				resp := tc.responses[calls]
+				calls++
+				for key, value := range resp.header {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(resp.status)
+				_, _ = w.Write([]byte(resp.body))
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			transport := newRetryTransport(nil)
+			transport.now = func() time.Time { return now }
+			transport.sleep = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			req, err := http.NewRequest(tc.method, server.URL, nil)
+			if tc.body != "" {
+				req, err = http.NewRequest(tc.method, server.URL, strings.NewReader(tc.body))
+			}
+			assert.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectCode, resp.StatusCode)
+			assert.Equal(t, tc.expectCalls, calls)
+			if assert.Len(t, waits, len(tc.expectWaits)) {
+				for i, wait := range tc.expectWaits {
+					// a negative wait expects a jittered backoff
+					if wait < 0 {
+						assert.GreaterOrEqual(t, waits[i], baseBackoff<<i/2)
+						assert.Less(t, waits[i], baseBackoff<<i)
+						continue
+					}
+					assert.Equal(t, wait, waits[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	transport := newRetryTransport(nil)
+	transport.sleep = func(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryTransportLogger(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(nil)
+	transport.sleep = func(context.Context, time.Duration) error { return nil }
+
+	// the retries are logged with the logger of the actor carried by the context
+	var buf bytes.Buffer
+	actorLogger := logging.WithActor(logging.NewHandler(logging.JSONFormat, &buf), "AssignActor")
+	req, err := http.NewRequestWithContext(logging.NewContext(t.Context(), actorLogger), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, buf.String(), `"actor":"AssignActor"`)
+	assert.Contains(t, buf.String(), "responded 502, retrying")
+}
@@ -0,0 +1,156 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+// actionTexts describe the kinds of changes in the step summary.
+var actionTexts = map[string]string{
+	actors.LabelAdded:        "added the `%s` label",
+	actors.LabelRemoved:      "removed the `%s` label",
+	actors.Commented:         "commented",
+	actors.Reacted:           "reacted with `%s`",
+	actors.Assigned:          "assigned @%s",
+	actors.Unassigned:        "unassigned @%s",
+	actors.StateChanged:      "changed the state to `%s`",
+	actors.ReviewRequested:   "requested the review of @%s",
+	actors.ReviewUnrequested: "removed the review request of @%s",
+	actors.Reviewed:          "submitted a `%s` review",
+	actors.JobRerun:          "reran the job %s",
+	actors.StatusPublished:   "published the `%s` commit status",
+}
+
+// writeStepSummary appends the Markdown report of the run to the job summary,
+// see https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions#adding-a-job-summary
+func writeStepSummary(path string, report *Report) error {
+	return appendFile(path, stepSummary(report))
+}
+
+// writeOutputs appends the outputs of the run to the step outputs, the lists
+// are JSON arrays so that the following steps can read them with 'fromJSON'.
+func writeOutputs(path string, report *Report) error {
+	var commands, handled, failed []string
+	for _, result := range report.Captured() {
+		switch result.Outcome {
+		case Handled:
+			handled = append(handled, result.Actor)
+			commands = append(commands, result.Commands...)
+		case Failed:
+			failed = append(failed, result.Actor)
+		}
+	}
+
+	outputs := []struct {
+		name  string
+		value any
+	}{
+		{name: "commands_run", value: commands},
+		{name: "labels_added", value: report.ActionValues(actors.LabelAdded)},
+		{name: "labels_removed", value: report.ActionValues(actors.LabelRemoved)},
+		{name: "actors_handled", value: handled},
+		{name: "actors_failed", value: failed},
+		{name: "failed", value: len(failed) != 0},
+	}
+	var b strings.Builder
+	for _, output := range outputs {
+		value, err := json.Marshal(output.value)
+		if err != nil {
+			return err
+		}
+		// marshal the empty lists as '[]' rather than 'null'
+		if string(value) == "null" {
+			value = []byte("[]")
+		}
+		fmt.Fprintf(&b, "%s=%s\n", output.name, value)
+	}
+
+	return appendFile(path, b.String())
+}
+
+func stepSummary(report *Report) string {
+	var b strings.Builder
+	b.WriteString("## actbot\n\n")
+	fmt.Fprintf(&b, "- Event: `%s`\n", report.Event)
+	if report.Number != 0 {
+		fmt.Fprintf(&b, "- Issue or pull request: #%d\n", report.Number)
+	}
+	if len(report.Sender) != 0 {
+		fmt.Fprintf(&b, "- Sender: @%s\n", report.Sender)
+	}
+
+	captured := report.Captured()
+	if len(captured) == 0 {
+		b.WriteString("\nNo actor captured the event.\n")
+		return b.String()
+	}
+	b.WriteString("\n### Actors\n\n")
+	b.WriteString("| Actor | Commands | Outcome |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, result := range captured {
+		commands := "-"
+		if len(result.Commands) != 0 {
+			commands = "`" + strings.Join(result.Commands, "`, `") + "`"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", result.Actor, commands, result.Outcome)
+	}
+
+	b.WriteString("\n### Actions\n\n")
+	if len(report.Actions) == 0 {
+		b.WriteString("No changes were made.\n")
+	}
+	for _, action := range report.Actions {
+		actor := action.Actor
+		if len(actor) == 0 {
+			actor = "actbot"
+		}
+		text := actionTexts[action.Kind]
+		if strings.Contains(text, "%s") {
+			text = fmt.Sprintf(text, action.Value)
+		}
+		if action.Number != 0 {
+			text += fmt.Sprintf(" on #%d", action.Number)
+		}
+		fmt.Fprintf(&b, "- %s %s\n", actor, text)
+	}
+
+	if failures := report.Failures(); len(failures) != 0 {
+		b.WriteString("\n### Errors\n\n")
+		for _, failure := range failures {
+			fmt.Fprintf(&b, "- %s: %s\n", failure.Actor, strings.ReplaceAll(failure.Err.Error(), "\n", " "))
+		}
+	}
+
+	return b.String()
+}
+
+func appendFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,140 @@
+// Copyright 2024-2025 the original author or authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ShyunnY/actbot/internal/actors"
+)
+
+func TestStepSummary(t *testing.T) {
+	cases := []struct {
+		caseName string
+		report   *Report
+		expect   string
+	}{
+		{
+			caseName: "No actor captured the event",
+			report: &Report{
+				Event:   string(Schedule),
+				Results: []Result{{Actor: "ClaimActor", Outcome: Skipped}},
+			},
+			expect: "## actbot\n\n" +
+				"- Event: `schedule`\n" +
+				"\nNo actor captured the event.\n",
+		},
+		{
+			caseName: "Comment handled by several actors",
+			report: &Report{
+				Event:  string(IssueComment),
+				Sender: "bob",
+				Number: 1,
+				Results: []Result{
+					{Actor: "AssignActor", Outcome: Skipped},
+					{Actor: "AreaLabelerActor", Outcome: Failed, Commands: []string{"/area foo"}, Err: errors.New("/area foo: unknown label")},
+					{Actor: "KindLabelerActor", Outcome: Handled, Commands: []string{"/kind bug"}},
+				},
+				Actions: []actors.Action{
+					{Actor: "KindLabelerActor", Kind: actors.LabelAdded, Number: 1, Value: "kind/bug"},
+					{Kind: actors.Commented, Number: 1, Value: "@bob sorry"},
+					{Kind: actors.Reacted, Value: actors.ConfusedReaction},
+				},
+			},
+			expect: "## actbot\n\n" +
+				"- Event: `issue_comment`\n" +
+				"- Issue or pull request: #1\n" +
+				"- Sender: @bob\n" +
+				"\n### Actors\n\n" +
+				"| Actor | Commands | Outcome |\n" +
+				"| --- | --- | --- |\n" +
+				"| AreaLabelerActor | `/area foo` | failed |\n" +
+				"| KindLabelerActor | `/kind bug` | handled |\n" +
+				"\n### Actions\n\n" +
+				"- KindLabelerActor added the `kind/bug` label on #1\n" +
+				"- actbot commented on #1\n" +
+				"- actbot reacted with `confused`\n" +
+				"\n### Errors\n\n" +
+				"- AreaLabelerActor: /area foo: unknown label\n",
+		},
+		{
+			caseName: "Event handled without changes",
+			report: &Report{
+				Event:   string(PullRequestTarget),
+				Sender:  "alice",
+				Number:  2,
+				Results: []Result{{Actor: "LGTMActor", Outcome: Handled}},
+			},
+			expect: "## actbot\n\n" +
+				"- Event: `pull_request_target`\n" +
+				"- Issue or pull request: #2\n" +
+				"- Sender: @alice\n" +
+				"\n### Actors\n\n" +
+				"| Actor | Commands | Outcome |\n" +
+				"| --- | --- | --- |\n" +
+				"| LGTMActor | - | handled |\n" +
+				"\n### Actions\n\n" +
+				"No changes were made.\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, stepSummary(tc.report))
+		})
+	}
+}
+
+func TestWriteOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	assert.NoError(t, os.WriteFile(path, []byte("previous=step\n"), 0o600))
+
+	report := &Report{
+		Event: string(IssueComment),
+		Results: []Result{
+			{Actor: "AssignActor", Outcome: Skipped},
+			{Actor: "AreaLabelerActor", Outcome: Handled, Commands: []string{"/area core", "/unarea api"}},
+			{Actor: "KindLabelerActor", Outcome: Failed, Commands: []string{"/kind foo"}, Err: errors.New("boom")},
+		},
+		Actions: []actors.Action{
+			{Actor: "AreaLabelerActor", Kind: actors.LabelAdded, Number: 1, Value: "area/core"},
+			{Actor: "AreaLabelerActor", Kind: actors.LabelRemoved, Number: 1, Value: "area/api"},
+			{Actor: "AreaLabelerActor", Kind: actors.LabelRemoved, Number: 1, Value: actors.NeedsTriageLabel},
+		},
+	}
+	assert.NoError(t, writeOutputs(path, report))
+	assert.NoError(t, writeOutputs(path, &Report{Event: string(Schedule)}))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous=step\n"+
+		`commands_run=["/area core","/unarea api"]`+"\n"+
+		`labels_added=["area/core"]`+"\n"+
+		`labels_removed=["area/api","needs-triage"]`+"\n"+
+		`actors_handled=["AreaLabelerActor"]`+"\n"+
+		`actors_failed=["KindLabelerActor"]`+"\n"+
+		"failed=true\n"+
+		"commands_run=[]\n"+
+		"labels_added=[]\n"+
+		"labels_removed=[]\n"+
+		"actors_handled=[]\n"+
+		"actors_failed=[]\n"+
+		"failed=false\n", string(content))
+}
@@ -24,5 +24,6 @@ import (
 func main() {
 	if err := internal.Setup(); err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }